@@ -0,0 +1,174 @@
+// Command soak load-tests termidar's data path the way a busy SSH deployment
+// exercises it: many concurrent sessions, each loading radar for random ZIP
+// codes, with a latency and error summary at the end. It runs against the
+// embedded fixtures by default; pass -live to hit the real backends.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/cache"
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/fixtures"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+	"github.com/N-Erickson/termidar/internal/radar"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// result is the outcome of one simulated load
+type result struct {
+	latency   time.Duration
+	err       error
+	simulated bool
+}
+
+func main() {
+	sessions := flag.Int("sessions", 10, "number of concurrent sessions")
+	loads := flag.Int("loads", 5, "radar loads per session")
+	think := flag.Duration("think", 0, "pause between a session's loads")
+	live := flag.Bool("live", false, "use the real geocoding, NWS and radar services instead of fixtures")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed for ZIP selection")
+	flag.Parse()
+
+	if *sessions < 1 || *loads < 1 {
+		fmt.Fprintln(os.Stderr, "Error: -sessions and -loads must be at least 1")
+		os.Exit(2)
+	}
+
+	prefs := config.LoadPreferences()
+	if *live {
+		cache.Configure(prefs.CacheSettings())
+	} else {
+		httpclient.Transport = fixtures.Transport()
+		cache.Configure(cache.Settings{})
+	}
+
+	zips := weather.OfflineZipCodes()
+	if len(zips) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no ZIP codes available")
+		os.Exit(1)
+	}
+
+	opts := radar.Options{
+		ObservationStations: prefs.ObservationStations(),
+		FrameInterval:       prefs.FrameInterval(),
+		Timeout:             prefs.LoadTimeout(),
+		RainViewerRetries:   prefs.RainViewerRetries,
+	}
+
+	fmt.Printf("Running %d sessions x %d loads against %s backends...\n", *sessions, *loads, backendName(*live))
+
+	results := make(chan result, *sessions**loads)
+	var wg sync.WaitGroup
+	start := time.Now()
+	for s := 0; s < *sessions; s++ {
+		wg.Add(1)
+		rng := rand.New(rand.NewSource(*seed + int64(s)))
+		go func() {
+			defer wg.Done()
+			for i := 0; i < *loads; i++ {
+				if i > 0 && *think > 0 {
+					time.Sleep(*think)
+				}
+				results <- load(zips[rng.Intn(len(zips))], opts)
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	report(results, time.Since(start))
+}
+
+// load runs one radar load to completion, as a session pressing Enter would
+func load(zip string, opts radar.Options) result {
+	began := time.Now()
+	msg := radar.Await(radar.LoadData(zip, opts))
+	r := result{latency: time.Since(began)}
+
+	switch msg := msg.(type) {
+	case radar.ErrorMsg:
+		r.err = msg.Err
+	case radar.LoadedMsg:
+		r.simulated = !msg.Radar.IsRealData
+	}
+	return r
+}
+
+// report prints the latency distribution and error breakdown
+func report(results <-chan result, elapsed time.Duration) {
+	var latencies []time.Duration
+	var failures, simulated int
+	errorCounts := map[string]int{}
+
+	for r := range results {
+		latencies = append(latencies, r.latency)
+		switch {
+		case r.err != nil:
+			failures++
+			errorCounts[r.err.Error()]++
+		case r.simulated:
+			simulated++
+		}
+	}
+
+	total := len(latencies)
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	fmt.Println()
+	fmt.Printf("Loads:      %d in %s (%.1f/s)\n", total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds())
+	fmt.Printf("Errors:     %d (%.1f%%)\n", failures, percent(failures, total))
+	fmt.Printf("Simulated:  %d (%.1f%%) fell back to generated frames\n", simulated, percent(simulated, total))
+	fmt.Printf("Latency:    p50 %s  p90 %s  p99 %s  max %s\n",
+		percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 99), latencies[total-1].Round(time.Millisecond))
+
+	if len(errorCounts) > 0 {
+		messages := make([]string, 0, len(errorCounts))
+		for msg := range errorCounts {
+			messages = append(messages, msg)
+		}
+		sort.Slice(messages, func(i, j int) bool { return errorCounts[messages[i]] > errorCounts[messages[j]] })
+
+		fmt.Println()
+		fmt.Println("Errors by message:")
+		for _, msg := range messages {
+			fmt.Printf("  %5d  %s\n", errorCounts[msg], strings.TrimSpace(msg))
+		}
+	}
+
+	if failures > 0 {
+		os.Exit(1)
+	}
+}
+
+// percentile returns the p-th percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	i := (len(sorted)*p + 99) / 100
+	if i > 0 {
+		i--
+	}
+	return sorted[i].Round(time.Millisecond)
+}
+
+// percent returns n as a percentage of total
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// backendName describes which backends the run targets
+func backendName(live bool) string {
+	if live {
+		return "live"
+	}
+	return "fixture"
+}
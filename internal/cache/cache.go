@@ -0,0 +1,284 @@
+// Package cache stores fetched data on disk between runs, bounded by a
+// per-kind time to live and a total size limit.
+package cache
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/atomicfile"
+)
+
+// Kinds of cached data; each kind lives in its own subdirectory
+const (
+	KindGeocode = "geocode"
+	KindFrame   = "frame"
+)
+
+// Settings bounds how long entries live and how much disk the cache may use
+type Settings struct {
+	// MaxBytes is the total size across all kinds before the least recently
+	// used entries are evicted; zero or less means unbounded
+	MaxBytes int64
+	// TTL is how long entries of each kind stay valid; a kind with no TTL, or
+	// a TTL of zero or less, is not cached at all
+	TTL map[string]time.Duration
+}
+
+// DefaultSettings is used until Configure is called
+func DefaultSettings() Settings {
+	return Settings{
+		MaxBytes: 50 << 20,
+		TTL: map[string]time.Duration{
+			KindGeocode: 30 * 24 * time.Hour,
+			KindFrame:   15 * time.Minute,
+		},
+	}
+}
+
+// envelope records when an entry was stored alongside its value; the file's
+// modification time instead tracks when it was last used
+type envelope struct {
+	Stored time.Time       `json:"stored"`
+	Value  json.RawMessage `json:"value"`
+}
+
+var (
+	mu       sync.Mutex
+	settings = DefaultSettings()
+)
+
+// evictTarget is the share of MaxBytes eviction trims the cache down to, so
+// the next few puts fit without walking the directory again
+const evictTarget = 0.9
+
+var (
+	// usageMu guards usage and serializes eviction; Put only takes it to
+	// update the running total
+	usageMu sync.Mutex
+	// usage is the cache's size on disk as tracked by Put, or -1 until it is
+	// next measured
+	usage int64 = -1
+)
+
+// Configure replaces the cache settings
+func Configure(s Settings) {
+	mu.Lock()
+	defer mu.Unlock()
+	settings = s
+
+	usageMu.Lock()
+	usage = -1
+	usageMu.Unlock()
+}
+
+// Dir returns the root directory of the on-disk cache
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "termidar"), nil
+}
+
+// Get decodes the entry for key into v, reporting whether a fresh entry existed
+func Get(kind, key string, v any) bool {
+	mu.Lock()
+	ttl := settings.TTL[kind]
+	mu.Unlock()
+
+	if ttl <= 0 {
+		return false
+	}
+
+	path, err := entryPath(kind, key)
+	if err != nil {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil || time.Since(e.Stored) > ttl {
+		removeEntry(path, int64(len(data)))
+		return false
+	}
+
+	if err := json.Unmarshal(e.Value, v); err != nil {
+		removeEntry(path, int64(len(data)))
+		return false
+	}
+
+	// Bump the modification time so eviction treats this entry as recently used
+	now := time.Now()
+	os.Chtimes(path, now, now)
+
+	return true
+}
+
+// Put stores v under key. The entry is written beside its final path and
+// renamed into place, so a concurrent Get never reads half of it. Caching is
+// best-effort, so failures are ignored.
+func Put(kind, key string, v any) {
+	mu.Lock()
+	ttl := settings.TTL[kind]
+	maxBytes := settings.MaxBytes
+	mu.Unlock()
+
+	if ttl <= 0 {
+		return
+	}
+
+	path, err := entryPath(kind, key)
+	if err != nil {
+		return
+	}
+
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(envelope{Stored: time.Now(), Value: value})
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+
+	if err := atomicfile.WriteFile(path, data, 0o644); err != nil {
+		return
+	}
+
+	if maxBytes > 0 {
+		grow(int64(len(data))-replaced, maxBytes)
+	}
+}
+
+// Clear removes every cached entry
+func Clear() error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	usage = -1
+	return os.RemoveAll(dir)
+}
+
+// removeEntry deletes an entry of the given size, such as one found expired
+// (private helper)
+func removeEntry(path string, size int64) {
+	if os.Remove(path) != nil {
+		return
+	}
+	usageMu.Lock()
+	defer usageMu.Unlock()
+	if usage >= 0 {
+		usage -= size
+	}
+}
+
+// grow adds delta bytes to the tracked usage, measuring it first if it isn't
+// known, and evicts once it passes maxBytes (private helper)
+func grow(delta, maxBytes int64) {
+	usageMu.Lock()
+	defer usageMu.Unlock()
+
+	if usage < 0 {
+		// The walk already counts the entry just written
+		usage = measure()
+	} else {
+		usage += delta
+	}
+
+	if usage > maxBytes {
+		usage = evict(int64(float64(maxBytes) * evictTarget))
+	}
+}
+
+// entryPath returns the file an entry is stored in (private helper)
+func entryPath(kind, key string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(dir, kind, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// cacheEntry is one file in the cache directory
+type cacheEntry struct {
+	path   string
+	size   int64
+	usedAt time.Time
+}
+
+// listEntries lists every file in the cache directory with its total size
+// (private helper)
+func listEntries() ([]cacheEntry, int64) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, 0
+	}
+
+	var entries []cacheEntry
+	var total int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), usedAt: info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	return entries, total
+}
+
+// measure returns the size of the cache on disk (private helper)
+func measure() int64 {
+	_, total := listEntries()
+	return total
+}
+
+// evict deletes the least recently used entries until the cache fits in
+// maxBytes, returning its size afterwards. It's called with usageMu held.
+// (private helper)
+func evict(maxBytes int64) int64 {
+	entries, total := listEntries()
+	if total <= maxBytes {
+		return total
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].usedAt.Before(entries[j].usedAt)
+	})
+
+	for _, e := range entries {
+		if total <= maxBytes {
+			break
+		}
+		if os.Remove(e.path) == nil {
+			total -= e.size
+		}
+	}
+	return total
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useTempCache points the cache at an empty directory for one test
+func useTempCache(t *testing.T, s Settings) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	Configure(s)
+	t.Cleanup(func() { Configure(DefaultSettings()) })
+}
+
+func TestGetExpired(t *testing.T) {
+	useTempCache(t, Settings{TTL: map[string]time.Duration{KindGeocode: time.Hour}})
+
+	Put(KindGeocode, "80202", "Denver")
+	var got string
+	if !Get(KindGeocode, "80202", &got) || got != "Denver" {
+		t.Fatalf("fresh entry: got %q", got)
+	}
+	if Get(KindFrame, "80202", &got) {
+		t.Error("entry found under another kind")
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	Configure(Settings{TTL: map[string]time.Duration{KindGeocode: time.Millisecond}})
+	if Get(KindGeocode, "80202", &got) {
+		t.Error("expired entry was returned")
+	}
+
+	path, _ := entryPath(KindGeocode, "80202")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expired entry was left on disk: %v", err)
+	}
+}
+
+func TestPutEvictsLeastRecentlyUsed(t *testing.T) {
+	ttl := map[string]time.Duration{KindFrame: time.Hour}
+	useTempCache(t, Settings{TTL: ttl})
+
+	value := strings.Repeat("x", 1000)
+	for _, key := range []string{"a", "b", "c"} {
+		Put(KindFrame, key, value)
+	}
+	path, _ := entryPath(KindFrame, "a")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := info.Size()
+
+	// b was used longest ago, then c, then a, which Get then uses again
+	now := time.Now()
+	for key, age := range map[string]time.Duration{"a": time.Hour, "b": 3 * time.Hour, "c": 2 * time.Hour} {
+		path, _ := entryPath(KindFrame, key)
+		os.Chtimes(path, now.Add(-age), now.Add(-age))
+	}
+	var got string
+	if !Get(KindFrame, "a", &got) {
+		t.Fatal("a missing before eviction")
+	}
+
+	// Room for two and a half entries, so the fourth evicts down to two
+	Configure(Settings{MaxBytes: size * 5 / 2, TTL: ttl})
+	Put(KindFrame, "d", value)
+
+	for key, kept := range map[string]bool{"a": true, "b": false, "c": false, "d": true} {
+		path, _ := entryPath(KindFrame, key)
+		_, err := os.Stat(path)
+		if exists := err == nil; exists != kept {
+			t.Errorf("%s: on disk %t, want %t", key, exists, kept)
+		}
+	}
+}
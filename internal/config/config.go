@@ -9,6 +9,10 @@ const (
 	RadarWidth  = 60
 	RadarHeight = 30
 	MaxFrames   = 20
+
+	// Ground distance covered by the radar grid
+	ViewWidthMiles  = 250.0
+	ViewHeightMiles = 150.0
 )
 
 // Styles
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// MaxNoteLength bounds a favorite's note, which has to fit in the info panel
+const MaxNoteLength = 40
+
+// Favorite is a saved location, with an optional note on why it matters
+type Favorite struct {
+	Query    string `json:"query"`
+	Location string `json:"location"`
+	Note     string `json:"note,omitempty"`
+}
+
+// favoritesPath returns the location of the favorites file (private helper)
+func favoritesPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "favorites.json"), nil
+}
+
+// LoadFavorites returns the saved locations in the order they were added. A
+// missing or unreadable file means no favorites.
+func LoadFavorites() []Favorite {
+	path, err := favoritesPath()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var favorites []Favorite
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil
+	}
+	return favorites
+}
+
+// SaveFavorites replaces the saved locations
+func SaveFavorites(favorites []Favorite) error {
+	path, err := favoritesPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(favorites, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// FindFavorite returns the index of the favorite saved for query, or -1
+func FindFavorite(favorites []Favorite, query string) int {
+	for i, favorite := range favorites {
+		if favorite.Query == query {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// maxHistory bounds how many recent locations are remembered
+const maxHistory = 10
+
+// HistoryEntry is one recently viewed location
+type HistoryEntry struct {
+	Query    string    `json:"query"`
+	Location string    `json:"location"`
+	Viewed   time.Time `json:"viewed"`
+}
+
+// historyPath returns the location of the recent history file (private helper)
+func historyPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.json"), nil
+}
+
+// LoadHistory returns recently viewed locations, newest first. A missing or
+// unreadable file is an empty history.
+func LoadHistory() []HistoryEntry {
+	path, err := historyPath()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var history []HistoryEntry
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil
+	}
+	return history
+}
+
+// RecordHistory moves a location to the front of the recent history
+func RecordHistory(query, location string) error {
+	entries := pushHistory(LoadHistory(), query, location)
+
+	path, err := historyPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// pushHistory puts a location in front of history, dropping its older entry
+// and anything past maxHistory (private helper)
+func pushHistory(history []HistoryEntry, query, location string) []HistoryEntry {
+	entries := []HistoryEntry{{Query: query, Location: location, Viewed: time.Now()}}
+	for _, entry := range history {
+		if entry.Query != query && len(entries) < maxHistory {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,433 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/atomicfile"
+	"github.com/N-Erickson/termidar/internal/cache"
+)
+
+// Frame rate bounds enforced by the playback speed controls
+const (
+	MinFrameRate     = 100 * time.Millisecond
+	MaxFrameRate     = 2 * time.Second
+	DefaultFrameRate = 300 * time.Millisecond
+)
+
+// Bounds for how long the loop holds on its newest frame
+const (
+	MaxHoldLast     = 10 * time.Second
+	DefaultHoldLast = 1500 * time.Millisecond
+)
+
+// Startup modes applied when radar data first loads
+const (
+	StartModeLoop   = "loop"   // animate from the oldest frame
+	StartModeLatest = "latest" // pause on the most recent frame
+)
+
+// Observation station strategies for the current conditions display
+const (
+	StationNearest        = "nearest"         // only ever use the closest station
+	StationFirstReporting = "first_reporting" // fall back to the next stations when one has no data
+)
+
+// Radar sources that can be forced in place of the automatic fallback chain
+const (
+	RadarSourceAuto       = ""
+	RadarSourceRainViewer = "rainviewer"
+	RadarSourceIowaState  = "iowa_state"
+	RadarSourceNWS        = "nws"
+	RadarSourceSimulated  = "simulated"
+)
+
+// RadarSources lists the radar source choices in cycling order
+var RadarSources = []string{
+	RadarSourceAuto,
+	RadarSourceRainViewer,
+	RadarSourceIowaState,
+	RadarSourceNWS,
+	RadarSourceSimulated,
+}
+
+// Filters for which alerts get their polygon drawn on the radar
+const (
+	PolygonFilterAll      = "all"      // every alert with a mapped area
+	PolygonFilterWarnings = "warnings" // warnings, not watches or advisories
+	PolygonFilterSevere   = "severe"   // Severe or Extreme severity
+	PolygonFilterTornado  = "tornado"  // tornado warnings only
+)
+
+// PolygonFilters lists the polygon filters in cycling order
+var PolygonFilters = []string{
+	PolygonFilterAll,
+	PolygonFilterWarnings,
+	PolygonFilterSevere,
+	PolygonFilterTornado,
+}
+
+// How a frame with no precipitation is called out
+const (
+	ClearNoticeCaption = "caption" // a line in the info panel
+	ClearNoticeOverlay = "overlay" // the caption, plus a label across a loop that is clear throughout
+	ClearNoticeOff     = "off"
+)
+
+// ClearNotices lists the clear-frame notices in cycling order
+var ClearNotices = []string{ClearNoticeCaption, ClearNoticeOverlay, ClearNoticeOff}
+
+// What to show when the window is smaller than the layout
+const (
+	SmallTerminalWarn = "warn" // replace the screen with a resize prompt
+	SmallTerminalClip = "clip" // draw anyway and let the terminal cut it off
+)
+
+// How decoded intensities map onto the precipitation colors
+const (
+	IntensityScaleLinear = "linear" // one color step per intensity step
+	IntensityScaleLog    = "log"    // steps weighted by rain rate, so heavy cores stand out
+)
+
+// Frame spacing choices for the radar loop, in minutes
+var FrameIntervals = []int{5, 10, 15}
+
+// stationFallbackLimit caps how many stations first_reporting will try
+const stationFallbackLimit = 3
+
+// CachePreferences bounds the on-disk cache
+type CachePreferences struct {
+	GeocodeTTLHours int `json:"geocode_ttl_hours"`
+	// FrameTTLMinutes is how long decoded radar frames are reused, so a
+	// relaunch or refresh only fetches the frames published since
+	FrameTTLMinutes int `json:"frame_ttl_minutes"`
+	MaxSizeMB       int `json:"max_size_mb"`
+}
+
+// RefreshPreferences bounds the auto-refresh interval, which lengthens while
+// the radar is quiet
+type RefreshPreferences struct {
+	Enabled    bool `json:"enabled"`
+	MinMinutes int  `json:"min_minutes"`
+	MaxMinutes int  `json:"max_minutes"`
+	// ActivityFrames is how many of the newest frames decide whether the
+	// radar is active; zero or less considers the whole loop
+	ActivityFrames int `json:"activity_frames"`
+}
+
+// Preferences holds user settings that persist between runs
+type Preferences struct {
+	FrameRateMS int              `json:"frame_rate_ms"`
+	Paused      bool             `json:"paused"`
+	StartMode   string           `json:"start_mode"`
+	HoldLastMS  int              `json:"hold_last_ms"`
+	Cache       CachePreferences `json:"cache"`
+	WindBarbs   bool             `json:"wind_barbs"`
+	PrecipBlend bool             `json:"precip_blend"`
+	// MapOnTop draws borders, cities and markers over all but heavy
+	// precipitation instead of beneath it
+	MapOnTop bool `json:"map_on_top"`
+	// PingPong plays the loop forward then back, pausing at each end,
+	// instead of jumping from the newest frame to the oldest
+	PingPong bool `json:"ping_pong"`
+	// Interpolate draws blended steps between adjacent frames for a smoother
+	// loop, at the cost of more redraws
+	Interpolate bool `json:"interpolate"`
+	// Cities marks the cities in view, more of them when zoomed in
+	Cities bool `json:"cities"`
+	// StateBorders, Waterways, Mountains and DistanceRings each draw one
+	// layer of the map beneath the radar; waterways are rivers, coastlines
+	// and the Great Lakes
+	StateBorders  bool `json:"state_borders"`
+	Waterways     bool `json:"waterways"`
+	Mountains     bool `json:"mountains"`
+	DistanceRings bool `json:"distance_rings"`
+	// Interstates draws the main Interstate Highways
+	Interstates bool `json:"interstates"`
+	// Graticule overlays faint whole-degree latitude and longitude lines
+	Graticule bool `json:"graticule"`
+	// HourlyForecast shows the next hours' temperature and chance of
+	// precipitation under the radar
+	HourlyForecast bool `json:"hourly_forecast"`
+	// Forecast shows the coming days' and nights' forecast under the radar
+	Forecast bool `json:"forecast"`
+	// ObservationStation picks which station current conditions come from
+	ObservationStation string `json:"observation_station"`
+	// FrameIntervalMin spaces loop frames further apart to cover more time
+	FrameIntervalMin int `json:"frame_interval_min"`
+	// ConfirmQuit asks before q or ctrl+c end the session (kiosk setups)
+	ConfirmQuit bool `json:"confirm_quit"`
+	// AlertPolygons outlines active warning areas on the radar
+	AlertPolygons bool `json:"alert_polygons"`
+	// AlertPolygonFilter limits which alerts get a polygon; the alert list
+	// still shows them all
+	AlertPolygonFilter string `json:"alert_polygon_filter"`
+	// Units is imperial or metric; empty means detect from the locale
+	Units string `json:"units,omitempty"`
+	// UTC shows absolute times in UTC, marked Z, instead of local time
+	UTC bool `json:"utc"`
+	// Refresh bounds how often radar data is reloaded
+	Refresh RefreshPreferences `json:"refresh"`
+	// ResumeLast reopens the most recently viewed location on launch
+	ResumeLast bool `json:"resume_last"`
+	// CustomSource is a WMS URL template radar frames are fetched from
+	// instead of the built-in sources
+	CustomSource string `json:"custom_source,omitempty"`
+	// RadarSource fetches frames from only this source; empty tries each in
+	// turn
+	RadarSource string `json:"radar_source,omitempty"`
+	// StationOverlay sharpens the loop with the nearest station's own scan
+	// within its range, doubling the frames downloaded
+	StationOverlay bool `json:"station_overlay"`
+	// LoadTimeoutSeconds bounds a whole radar load; zero or less waits as
+	// long as the individual requests take
+	LoadTimeoutSeconds int `json:"load_timeout_seconds"`
+	// RainViewerRetries is how many more times the RainViewer frame index
+	// is requested after a transient failure before falling back
+	RainViewerRetries int `json:"rainviewer_retries"`
+	// ClearNotice says how frames without precipitation are called out, so
+	// an empty map doesn't look like a failed load
+	ClearNotice string `json:"clear_notice"`
+	// SmallTerminal says what to draw when the window is too small for the
+	// current view
+	SmallTerminal string `json:"small_terminal"`
+	// IntensityScale picks how intensities map onto precipitation colors
+	IntensityScale string `json:"intensity_scale"`
+	// Legend shows the precipitation color key under the radar
+	Legend bool `json:"legend"`
+	// TextExportANSI keeps the colors in a frame saved as text; otherwise
+	// it's plain characters for monochrome terminals and plain-text chats
+	TextExportANSI bool `json:"text_export_ansi"`
+	// TerminalTitle keeps the terminal title set to the location, its
+	// temperature and the most severe alert
+	TerminalTitle bool `json:"terminal_title"`
+	// StaleAfterMinutes is how old the last successful load can get before
+	// the display is marked stale; zero or less never marks it
+	StaleAfterMinutes int `json:"stale_after_minutes"`
+	// ReconnectSeconds is how long to wait before loading again when a load
+	// failed because a service couldn't be reached; zero or less waits for ESC
+	ReconnectSeconds int `json:"reconnect_seconds"`
+}
+
+// DefaultPreferences returns the preferences used when no config file exists
+func DefaultPreferences() Preferences {
+	return Preferences{
+		FrameRateMS:        int(DefaultFrameRate / time.Millisecond),
+		StartMode:          StartModeLoop,
+		HoldLastMS:         int(DefaultHoldLast / time.Millisecond),
+		ObservationStation: StationFirstReporting,
+		FrameIntervalMin:   FrameIntervals[0],
+		AlertPolygons:      true,
+		AlertPolygonFilter: PolygonFilterAll,
+		LoadTimeoutSeconds: 30,
+		RainViewerRetries:  2,
+		ClearNotice:        ClearNoticeCaption,
+		SmallTerminal:      SmallTerminalWarn,
+		IntensityScale:     IntensityScaleLinear,
+		Legend:             true,
+		Cities:             true,
+		StateBorders:       true,
+		Waterways:          true,
+		Mountains:          true,
+		DistanceRings:      true,
+		TerminalTitle:      true,
+		TextExportANSI:     true,
+		StaleAfterMinutes:  45,
+		ReconnectSeconds:   30,
+		Cache: CachePreferences{
+			GeocodeTTLHours: 30 * 24,
+			FrameTTLMinutes: 15,
+			MaxSizeMB:       50,
+		},
+		Refresh: RefreshPreferences{
+			Enabled:        true,
+			MinMinutes:     5,
+			MaxMinutes:     30,
+			ActivityFrames: 6,
+		},
+	}
+}
+
+// FrameRate returns the saved animation frame rate clamped to the supported range
+func (p Preferences) FrameRate() time.Duration {
+	rate := time.Duration(p.FrameRateMS) * time.Millisecond
+	if rate < MinFrameRate {
+		return MinFrameRate
+	}
+	if rate > MaxFrameRate {
+		return MaxFrameRate
+	}
+	return rate
+}
+
+// HoldLast returns how long the loop stays on its newest frame before
+// starting over, zero for no longer than any other frame
+func (p Preferences) HoldLast() time.Duration {
+	hold := time.Duration(p.HoldLastMS) * time.Millisecond
+	return max(0, min(MaxHoldLast, hold))
+}
+
+// CacheSettings returns the on-disk cache bounds the preferences ask for
+func (p Preferences) CacheSettings() cache.Settings {
+	return cache.Settings{
+		MaxBytes: int64(p.Cache.MaxSizeMB) << 20,
+		TTL: map[string]time.Duration{
+			cache.KindGeocode: time.Duration(p.Cache.GeocodeTTLHours) * time.Hour,
+			cache.KindFrame:   time.Duration(p.Cache.FrameTTLMinutes) * time.Minute,
+		},
+	}
+}
+
+// ObservationStations returns how many observation stations to try for
+// current conditions under the configured strategy
+func (p Preferences) ObservationStations() int {
+	if p.ObservationStation == StationNearest {
+		return 1
+	}
+	return stationFallbackLimit
+}
+
+// FrameInterval returns the saved loop spacing, falling back to the closest
+// supported interval at or above it
+func (p Preferences) FrameInterval() time.Duration {
+	for _, minutes := range FrameIntervals {
+		if p.FrameIntervalMin <= minutes {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return time.Duration(FrameIntervals[len(FrameIntervals)-1]) * time.Minute
+}
+
+// LoadTimeout returns the overall budget for a radar load, zero for none
+func (p Preferences) LoadTimeout() time.Duration {
+	if p.LoadTimeoutSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(p.LoadTimeoutSeconds) * time.Second
+}
+
+// StaleAfter returns how old loaded data can get before it's flagged as
+// stale, zero for never
+func (p Preferences) StaleAfter() time.Duration {
+	if p.StaleAfterMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(p.StaleAfterMinutes) * time.Minute
+}
+
+// ReconnectDelay returns how long to wait before retrying a load that
+// couldn't reach a service, zero for never
+func (p Preferences) ReconnectDelay() time.Duration {
+	if p.ReconnectSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(p.ReconnectSeconds) * time.Second
+}
+
+// RefreshMin returns the shortest auto-refresh interval, used while
+// precipitation or alerts are present
+func (p Preferences) RefreshMin() time.Duration {
+	minInterval, _ := p.RefreshBounds()
+	return minInterval
+}
+
+// RefreshBounds returns the auto-refresh interval range, never shorter than
+// a minute and never inverted
+func (p Preferences) RefreshBounds() (time.Duration, time.Duration) {
+	minInterval := time.Duration(p.Refresh.MinMinutes) * time.Minute
+	maxInterval := time.Duration(p.Refresh.MaxMinutes) * time.Minute
+	if minInterval < time.Minute {
+		minInterval = time.Minute
+	}
+	if maxInterval < minInterval {
+		maxInterval = minInterval
+	}
+	return minInterval, maxInterval
+}
+
+// Dir returns the directory termidar keeps its config files in
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "termidar"), nil
+}
+
+// preferencesPath returns the location of the preferences file (private helper)
+func preferencesPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// LoadPreferences reads the preferences file, falling back to defaults for
+// anything missing or unreadable
+func LoadPreferences() Preferences {
+	prefs := DefaultPreferences()
+
+	path, err := preferencesPath()
+	if err != nil {
+		return prefs
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return prefs
+	}
+
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return DefaultPreferences()
+	}
+
+	return prefs
+}
+
+// preferencesMu serializes writes to the preferences file, which are made from
+// background commands that can overlap
+var preferencesMu sync.Mutex
+
+// SavePreferences writes the preferences file, creating the config directory if needed
+func SavePreferences(prefs Preferences) error {
+	preferencesMu.Lock()
+	defer preferencesMu.Unlock()
+	return savePreferences(prefs)
+}
+
+// savePreferences writes the preferences file with preferencesMu held
+// (private helper)
+func savePreferences(prefs Preferences) error {
+	path, err := preferencesPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return atomicfile.WriteFile(path, data, 0o644)
+}
+
+// UpdatePreferences applies a single change to the saved preferences file.
+// Reloading first keeps command-line overrides for the current session out
+// of the file, and holding preferencesMu across the reload and the save
+// keeps overlapping changes from undoing each other.
+func UpdatePreferences(change func(*Preferences)) error {
+	preferencesMu.Lock()
+	defer preferencesMu.Unlock()
+
+	prefs := LoadPreferences()
+	change(&prefs)
+	return savePreferences(prefs)
+}
@@ -0,0 +1,112 @@
+package config
+
+import "sync"
+
+// Store keeps what a session remembers between launches. The desktop app
+// uses FileStore; the SSH server gives every session its own MemoryStore so
+// one visitor never sees another's locations or changes their settings.
+type Store interface {
+	LoadPreferences() Preferences
+	SavePreferences(prefs Preferences) error
+	UpdatePreferences(change func(*Preferences)) error
+	LoadFavorites() []Favorite
+	SaveFavorites(favorites []Favorite) error
+	LoadHistory() []HistoryEntry
+	RecordHistory(query, location string) error
+}
+
+// FileStore keeps everything in the files under Dir
+type FileStore struct{}
+
+// LoadPreferences reads config.json
+func (FileStore) LoadPreferences() Preferences { return LoadPreferences() }
+
+// SavePreferences replaces config.json
+func (FileStore) SavePreferences(prefs Preferences) error { return SavePreferences(prefs) }
+
+// UpdatePreferences applies one change to config.json
+func (FileStore) UpdatePreferences(change func(*Preferences)) error {
+	return UpdatePreferences(change)
+}
+
+// LoadFavorites reads favorites.json
+func (FileStore) LoadFavorites() []Favorite { return LoadFavorites() }
+
+// SaveFavorites replaces favorites.json
+func (FileStore) SaveFavorites(favorites []Favorite) error { return SaveFavorites(favorites) }
+
+// LoadHistory reads history.json
+func (FileStore) LoadHistory() []HistoryEntry { return LoadHistory() }
+
+// RecordHistory updates history.json
+func (FileStore) RecordHistory(query, location string) error {
+	return RecordHistory(query, location)
+}
+
+// MemoryStore keeps everything in memory for the life of one session. It is
+// safe to use from the background commands that save changes.
+type MemoryStore struct {
+	mu        sync.Mutex
+	prefs     Preferences
+	favorites []Favorite
+	history   []HistoryEntry
+}
+
+// NewMemoryStore returns an in-memory store starting from prefs, with no
+// favorites or history
+func NewMemoryStore(prefs Preferences) *MemoryStore {
+	return &MemoryStore{prefs: prefs}
+}
+
+// LoadPreferences returns the session's preferences
+func (s *MemoryStore) LoadPreferences() Preferences {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prefs
+}
+
+// SavePreferences replaces the session's preferences
+func (s *MemoryStore) SavePreferences(prefs Preferences) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefs = prefs
+	return nil
+}
+
+// UpdatePreferences applies one change to the session's preferences
+func (s *MemoryStore) UpdatePreferences(change func(*Preferences)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change(&s.prefs)
+	return nil
+}
+
+// LoadFavorites returns a copy of the session's favorites
+func (s *MemoryStore) LoadFavorites() []Favorite {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Favorite(nil), s.favorites...)
+}
+
+// SaveFavorites replaces the session's favorites
+func (s *MemoryStore) SaveFavorites(favorites []Favorite) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.favorites = append([]Favorite(nil), favorites...)
+	return nil
+}
+
+// LoadHistory returns a copy of the session's recent locations
+func (s *MemoryStore) LoadHistory() []HistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]HistoryEntry(nil), s.history...)
+}
+
+// RecordHistory moves a location to the front of the session's history
+func (s *MemoryStore) RecordHistory(query, location string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = pushHistory(s.history, query, location)
+	return nil
+}
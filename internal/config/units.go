@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Measurement systems for the units preference
+const (
+	UnitsImperial = "imperial"
+	UnitsMetric   = "metric"
+)
+
+// imperialRegions are the locale territories that measure in US customary units
+var imperialRegions = map[string]bool{
+	"US": true,
+	"LR": true,
+	"MM": true,
+}
+
+// LocaleUnits guesses the measurement system from this process's locale
+func LocaleUnits() string {
+	return EnvironUnits(os.Environ())
+}
+
+// EnvironUnits guesses the measurement system from environment variables in
+// KEY=value form, such as those an SSH client forwards, reading LC_ALL,
+// LC_MEASUREMENT and LANG in POSIX precedence order. Locales without a
+// territory, such as C or POSIX, fall back to imperial.
+func EnvironUnits(environ []string) string {
+	vars := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if name, value, ok := strings.Cut(kv, "="); ok {
+			vars[name] = value
+		}
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MEASUREMENT", "LANG"} {
+		if value := vars[name]; value != "" {
+			return unitsForLocale(value)
+		}
+	}
+	return UnitsImperial
+}
+
+// unitsForLocale maps a locale such as en_GB.UTF-8 to its measurement system
+// (private helper)
+func unitsForLocale(locale string) string {
+	// Drop the codeset and modifier: en_GB.UTF-8@euro -> en_GB
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+
+	_, region, ok := strings.Cut(locale, "_")
+	if !ok {
+		_, region, ok = strings.Cut(locale, "-")
+	}
+	if !ok || region == "" {
+		return UnitsImperial
+	}
+
+	if imperialRegions[strings.ToUpper(region)] {
+		return UnitsImperial
+	}
+	return UnitsMetric
+}
@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestUnitsForLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en_US", UnitsImperial},
+		{"en_GB.UTF-8", UnitsMetric},
+		{"C", UnitsImperial},
+		{"de-DE", UnitsMetric},
+		{"my_MM", UnitsImperial},
+	}
+
+	for _, tt := range tests {
+		if got := unitsForLocale(tt.locale); got != tt.want {
+			t.Errorf("unitsForLocale(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestEnvironUnits(t *testing.T) {
+	tests := []struct {
+		name    string
+		environ []string
+		want    string
+	}{
+		{"none", nil, UnitsImperial},
+		{"LANG", []string{"LANG=fr_FR.UTF-8"}, UnitsMetric},
+		{"LC_ALL over LANG", []string{"LANG=fr_FR.UTF-8", "LC_ALL=en_US.UTF-8"}, UnitsImperial},
+		{"LC_MEASUREMENT over LANG", []string{"LC_MEASUREMENT=en_AU.UTF-8", "LANG=en_US.UTF-8"}, UnitsMetric},
+		{"empty LC_ALL skipped", []string{"LC_ALL=", "LANG=en_GB.UTF-8"}, UnitsMetric},
+	}
+
+	for _, tt := range tests {
+		if got := EnvironUnits(tt.environ); got != tt.want {
+			t.Errorf("%s: EnvironUnits = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
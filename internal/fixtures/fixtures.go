@@ -0,0 +1,168 @@
+// Package fixtures serves embedded sample responses in place of the live
+// geocoding, NWS, and radar services so termidar can run fully offline.
+package fixtures
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed data
+var files embed.FS
+
+// frameCount is the number of embedded radar frames (data/radar_N.png)
+const frameCount = 6
+
+// Transport returns an http.RoundTripper that answers every request from the
+// embedded fixtures. Timestamps in the responses are generated relative to the
+// current time so the sample data always looks fresh.
+func Transport() http.RoundTripper {
+	return roundTripper{}
+}
+
+type roundTripper struct{}
+
+// RoundTrip maps a request onto the fixture that stands in for it
+func (roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	path := req.URL.Path
+
+	switch {
+	case host == "api.zippopotam.us":
+		return serveFile(req, "data/zippopotam.json", "application/json")
+
+	case host == "api.weather.gov" && strings.HasPrefix(path, "/points/"):
+		return serveFile(req, "data/points.json", "application/geo+json")
+
+	case host == "api.weather.gov" && strings.HasSuffix(path, "/forecast/hourly"):
+		return serveFile(req, "data/forecast_hourly.json", "application/geo+json")
+
+	case host == "api.weather.gov" && strings.HasSuffix(path, "/forecast"):
+		return serveFile(req, "data/forecast.json", "application/geo+json")
+
+	case host == "api.weather.gov" && strings.HasSuffix(path, "/stations"):
+		return serveFile(req, "data/stations.json", "application/geo+json")
+
+	case host == "api.weather.gov" && strings.HasSuffix(path, "/observations/latest"):
+		return serveFile(req, "data/observation.json", "application/geo+json")
+
+	case host == "api.weather.gov" && strings.HasPrefix(path, "/alerts/"):
+		return serveFile(req, "data/alerts.json", "application/geo+json")
+
+	case host == "api.rainviewer.com":
+		return serveRainViewerIndex(req)
+
+	case host == "tilecache.rainviewer.com":
+		var frame int
+		if _, err := fmt.Sscanf(path, "/fixtures/radar/%d/", &frame); err != nil || frame < 0 || frame >= frameCount {
+			return notFound(req), nil
+		}
+		return serveFile(req, fmt.Sprintf("data/radar_%d.png", frame), "image/png")
+
+	case host == "mesonet.agron.iastate.edu" && strings.Contains(path, "ridge"):
+		return serveFile(req, "data/velocity.png", "image/png")
+
+	case host == "mesonet.agron.iastate.edu", host == "opengeo.ncep.noaa.gov":
+		return serveFile(req, "data/radar_0.png", "image/png")
+	}
+
+	return notFound(req), nil
+}
+
+// serveRainViewerIndex builds a weather-maps.json index whose past frames
+// point at the embedded radar images, spaced ten minutes apart ending now
+func serveRainViewerIndex(req *http.Request) (*http.Response, error) {
+	type entry struct {
+		Time int64  `json:"time"`
+		Path string `json:"path"`
+	}
+
+	var index struct {
+		Radar struct {
+			Past    []entry `json:"past"`
+			Nowcast []entry `json:"nowcast"`
+		} `json:"radar"`
+	}
+
+	latest := time.Now().Truncate(10 * time.Minute)
+	for i := 0; i < frameCount; i++ {
+		t := latest.Add(time.Duration(i-frameCount+1) * 10 * time.Minute)
+		index.Radar.Past = append(index.Radar.Past, entry{
+			Time: t.Unix(),
+			Path: fmt.Sprintf("/fixtures/radar/%d", i),
+		})
+	}
+
+	body, err := json.Marshal(index)
+	if err != nil {
+		return nil, err
+	}
+	return respond(req, http.StatusOK, "application/json", body), nil
+}
+
+// templateData supplies the placeholders available to JSON fixtures
+type templateData struct {
+	Now, Past, Future string
+	now               time.Time
+}
+
+// Hour returns the start of the hour n hours from now, for forecast periods
+func (d templateData) Hour(n int) string {
+	return d.now.Truncate(time.Hour).Add(time.Duration(n) * time.Hour).Format(time.RFC3339)
+}
+
+// serveFile responds with an embedded file, expanding {{.Now}}, {{.Past}},
+// {{.Future}}, and {{.Hour n}} placeholders in JSON fixtures
+func serveFile(req *http.Request, name, contentType string) (*http.Response, error) {
+	body, err := files.ReadFile(name)
+	if err != nil {
+		return notFound(req), nil
+	}
+
+	if strings.HasSuffix(name, ".json") {
+		tmpl, err := template.New(name).Parse(string(body))
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now().UTC()
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, templateData{
+			Now:    now.Format(time.RFC3339),
+			Past:   now.Add(-2 * time.Hour).Format(time.RFC3339),
+			Future: now.Add(6 * time.Hour).Format(time.RFC3339),
+			now:    now,
+		})
+		if err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+
+	return respond(req, http.StatusOK, contentType, body), nil
+}
+
+func notFound(req *http.Request) *http.Response {
+	return respond(req, http.StatusNotFound, "text/plain", []byte("no fixture for "+req.URL.String()))
+}
+
+func respond(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{contentType}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,68 @@
+package geography
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// alertSeverityColors colors warning outlines by NWS severity
+var alertSeverityColors = map[string]lipgloss.Color{
+	"Extreme":  lipgloss.Color("196"),
+	"Severe":   lipgloss.Color("208"),
+	"Moderate": lipgloss.Color("226"),
+	"Minor":    lipgloss.Color("39"),
+}
+
+// alertSeverityOrder draws milder alerts first so severe outlines end up on top
+var alertSeverityOrder = map[string]int{
+	"Minor":    1,
+	"Moderate": 2,
+	"Severe":   3,
+	"Extreme":  4,
+}
+
+// DrawAlertPolygons outlines the area of each alert that has a polygon. It is
+// drawn over precipitation, so the warning box stays visible inside the storm.
+// The alert at index highlight, if any, is drawn last with a double line.
+func DrawAlertPolygons(display [][]string, proj Projection, alerts []weather.Alert, highlight int) {
+	ordered := make([]int, 0, len(alerts))
+	for i, alert := range alerts {
+		if len(alert.Polygon) >= 2 && i != highlight {
+			ordered = append(ordered, i)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return alertSeverityOrder[alerts[ordered[i]].Severity] < alertSeverityOrder[alerts[ordered[j]].Severity]
+	})
+
+	for _, i := range ordered {
+		drawAlertOutline(display, proj, alerts[i], "━", "┃")
+	}
+	if highlight >= 0 && highlight < len(alerts) && len(alerts[highlight].Polygon) >= 2 {
+		drawAlertOutline(display, proj, alerts[highlight], "═", "║")
+	}
+}
+
+// drawAlertOutline draws one alert polygon with the given horizontal and
+// vertical glyphs (private helper)
+func drawAlertOutline(display [][]string, proj Projection, alert weather.Alert, horizontal, vertical string) {
+	color, ok := alertSeverityColors[alert.Severity]
+	if !ok {
+		color = lipgloss.Color("250")
+	}
+	style := lipgloss.NewStyle().Foreground(color).Bold(true)
+
+	for i := 0; i < len(alert.Polygon)-1; i++ {
+		startX, startY := proj.ToDisplay(alert.Polygon[i][0], alert.Polygon[i][1])
+		endX, endY := proj.ToDisplay(alert.Polygon[i+1][0], alert.Polygon[i+1][1])
+
+		char := horizontal
+		if absInt(startX-endX) < absInt(startY-endY) {
+			char = vertical
+		}
+		drawLine(display, startX, startY, endX, endY, char, &style, false)
+	}
+}
@@ -0,0 +1,24 @@
+package geography
+
+import (
+	_ "embed"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statesGeoJSON holds the US state outlines: Natural Earth's 1:10m admin-1
+// states (public domain), outer rings only, simplified to about 0.01° with
+// islands too small for the grid dropped. Any GeoJSON polygons or lines with
+// [lon, lat] coordinates can replace it.
+//
+//go:embed data/states.geojson
+var statesGeoJSON []byte
+
+// stateBorders is the state outlines layer
+var stateBorders = &geoLayer{name: "state borders", data: statesGeoJSON}
+
+// drawStateOutlines traces the embedded state outlines in the view, drawing
+// each step as │ or ─ by its direction on the grid
+func drawStateOutlines(display [][]string, proj Projection, style lipgloss.Style) {
+	drawPolylines(display, proj, stateBorders.polylines(), lineStyle{style: style, horizontal: "─", vertical: "│"})
+}
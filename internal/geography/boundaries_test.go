@@ -0,0 +1,64 @@
+package geography
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDistanceRingsAreCircular(t *testing.T) {
+	for _, scale := range []float64{0.5, 1, 2} {
+		proj := denver.Zoomed(scale)
+		display := blankDisplay()
+		DrawDistanceMarkers(display, proj)
+
+		// Every dot lies within a cell of one of the rings, measured in miles
+		tolerance := max(proj.MilesPerCellX, proj.MilesPerCellY)
+		dots := 0
+		for y, row := range display {
+			for x, ch := range row {
+				if ansi.Strip(ch) != "·" {
+					continue
+				}
+				dots++
+				miles := math.Hypot(float64(x-proj.CenterX)*proj.MilesPerCellX, float64(y-proj.CenterY)*proj.MilesPerCellY)
+				onRing := false
+				for _, ring := range ringMiles {
+					onRing = onRing || math.Abs(miles-ring) <= tolerance
+				}
+				if !onRing {
+					t.Errorf("scale %g: dot at (%d, %d) is %.0f miles out, on no ring", scale, x, y, miles)
+				}
+			}
+		}
+		if dots == 0 {
+			t.Errorf("scale %g: no rings drawn", scale)
+		}
+	}
+}
+
+func TestDistanceRingLabels(t *testing.T) {
+	display := blankDisplay()
+	DrawDistanceMarkers(display, denver)
+
+	// The standard view is 250 miles across, so the 150-mile ring is off the
+	// grid; the others are labeled where they cross the center row
+	row := ansi.Strip(strings.Join(display[denver.CenterY], ""))
+	for _, label := range []string{"50mi", "100mi"} {
+		if !strings.Contains(row, label) {
+			t.Errorf("center row %q is missing the %s label", row, label)
+		}
+	}
+	if strings.Contains(row, "150mi") {
+		t.Errorf("center row %q labels a ring outside the view", row)
+	}
+
+	// Zoomed out, the 150-mile ring comes into view
+	zoomed := blankDisplay()
+	DrawDistanceMarkers(zoomed, denver.Zoomed(2))
+	if row := ansi.Strip(strings.Join(zoomed[denver.CenterY], "")); !strings.Contains(row, "150mi") {
+		t.Errorf("zoomed-out center row %q is missing the 150mi label", row)
+	}
+}
@@ -0,0 +1,124 @@
+package geography
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// citiesCSV lists US urban areas largest first, from Natural Earth's
+// LandScan urban areas (public domain), placed at the middle of each area
+//
+//go:embed data/cities.csv
+var citiesCSV []byte
+
+// City is a populated place that can be marked on the map
+type City struct {
+	Name       string
+	Lat, Lon   float64
+	Population int
+}
+
+// cityMinPopulation is the smallest city marked in the standard view. It
+// grows with the area a zoomed-out view covers and shrinks when zoomed in.
+const cityMinPopulation = 100000
+
+// cityLabelMax is the longest label drawn; longer names are abbreviated
+const cityLabelMax = 10
+
+var (
+	citiesOnce sync.Once
+	cities     []City
+)
+
+// loadCities parses the embedded city table once (private helper)
+func loadCities() []City {
+	citiesOnce.Do(func() {
+		records, err := csv.NewReader(bytes.NewReader(citiesCSV)).ReadAll()
+		if err != nil {
+			log.Printf("Failed to parse city table: %v", err)
+			return
+		}
+
+		// Skip the header row
+		for _, rec := range records[1:] {
+			lat, errLat := strconv.ParseFloat(rec[1], 64)
+			lon, errLon := strconv.ParseFloat(rec[2], 64)
+			population, errPop := strconv.Atoi(rec[3])
+			if errLat != nil || errLon != nil || errPop != nil {
+				continue
+			}
+			cities = append(cities, City{Name: rec[0], Lat: lat, Lon: lon, Population: population})
+		}
+	})
+	return cities
+}
+
+// DrawCities marks the cities in view with a • and their name beside it,
+// largest first so they get the room when labels would collide. A city
+// whose label doesn't fit on either side keeps only its marker. Only empty
+// cells are drawn, and the population threshold follows the zoom: a
+// zoomed-in view shows small towns, a wide one only major metros.
+func DrawCities(display [][]string, proj Projection) {
+	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("244"))
+
+	// Ground covered relative to the standard view, by area
+	scale := proj.MilesPerCellX * float64(config.RadarWidth) / config.ViewWidthMiles
+	minPopulation := int(cityMinPopulation * scale * scale)
+
+	for _, city := range loadCities() {
+		if city.Population < minPopulation {
+			continue
+		}
+		x, y := proj.ToDisplay(city.Lat, city.Lon)
+		if !inDisplay(display, x, y) || display[y][x] != " " {
+			continue
+		}
+		display[y][x] = markerStyle.Render("•")
+
+		label := []rune(abbreviateCity(city.Name))
+		width := len(label)
+		// To the right, else to the left, with a blank cell either side so
+		// it can't run into another label
+		for _, start := range []int{x + 2, x - 1 - width} {
+			if !labelFits(display, y, start, width) {
+				continue
+			}
+			for i, ch := range label {
+				display[y][start+i] = labelStyle.Render(string(ch))
+			}
+			break
+		}
+	}
+}
+
+// abbreviateCity shortens a name longer than cityLabelMax, ending it with a
+// period (private helper)
+func abbreviateCity(name string) string {
+	runes := []rune(name)
+	if len(runes) <= cityLabelMax {
+		return name
+	}
+	return string(runes[:cityLabelMax-1]) + "."
+}
+
+// labelFits reports whether a label of width cells starting at x on row y
+// lies on the grid over blank cells, with a blank or the grid's edge on
+// either side (private helper)
+func labelFits(display [][]string, y, x, width int) bool {
+	for i := x - 1; i <= x+width; i++ {
+		onGrid := inDisplay(display, i, y)
+		if (onGrid && display[y][i] != " ") || (!onGrid && i >= x && i < x+width) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package geography
+
+import (
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// inDisplay reports whether a cell lies within the display grid
+func inDisplay(display [][]string, x, y int) bool {
+	return y >= 0 && y < len(display) && x >= 0 && x < len(display[0])
+}
+
+// clipLine cuts the line between two cells down to the part inside a grid
+// of the given size, using Liang-Barsky clipping so the part kept lies on the
+// original line. It reports false when the line misses the grid entirely.
+func clipLine(x1, y1, x2, y2, width, height int) (int, int, int, int, bool) {
+	dx, dy := float64(x2-x1), float64(y2-y1)
+	t0, t1 := 0.0, 1.0
+
+	// Each edge as p*t <= q: left, right, top, bottom
+	edges := [4][2]float64{
+		{-dx, float64(x1)},
+		{dx, float64(width - 1 - x1)},
+		{-dy, float64(y1)},
+		{dy, float64(height - 1 - y1)},
+	}
+	for _, edge := range edges {
+		p, q := edge[0], edge[1]
+		if p == 0 {
+			// Parallel to this edge, so wholly inside or outside it
+			if q < 0 {
+				return 0, 0, 0, 0, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return 0, 0, 0, 0, false
+			}
+			t0 = max(t0, t)
+		} else {
+			if t < t0 {
+				return 0, 0, 0, 0, false
+			}
+			t1 = min(t1, t)
+		}
+	}
+
+	point := func(t float64) (int, int) {
+		return x1 + int(math.Round(t*dx)), y1 + int(math.Round(t*dy))
+	}
+	cx1, cy1 := point(t0)
+	cx2, cy2 := point(t1)
+	return cx1, cy1, cx2, cy2, true
+}
+
+// drawLine draws a straight line of char between two display cells. The line
+// is clipped to the grid first, so one running out of view keeps its slope
+// rather than bending toward the edge. With skipExisting set, cells that
+// already hold something are left alone.
+func drawLine(display [][]string, x1, y1, x2, y2 int, char string, style *lipgloss.Style, skipExisting bool) {
+	plotLine(display, x1, y1, x2, y2, style.Render(char), skipExisting)
+}
+
+// plotLine is drawLine with the glyph already rendered, for callers tracing
+// many segments in one style (private helper)
+func plotLine(display [][]string, x1, y1, x2, y2 int, glyph string, skipExisting bool) {
+	if len(display) == 0 {
+		return
+	}
+
+	x1, y1, x2, y2, visible := clipLine(x1, y1, x2, y2, len(display[0]), len(display))
+	if !visible {
+		return
+	}
+
+	plot := func(x, y int) {
+		if !inDisplay(display, x, y) {
+			return
+		}
+		if skipExisting && display[y][x] != " " {
+			return
+		}
+		display[y][x] = glyph
+	}
+
+	if x1 == x2 { // Vertical line
+		if y1 > y2 {
+			y1, y2 = y2, y1
+		}
+		for y := y1; y <= y2; y++ {
+			plot(x1, y)
+		}
+	} else if y1 == y2 { // Horizontal line
+		if x1 > x2 {
+			x1, x2 = x2, x1
+		}
+		for x := x1; x <= x2; x++ {
+			plot(x, y1)
+		}
+	} else { // Diagonal line
+		dx := absInt(x2 - x1)
+		dy := absInt(y2 - y1)
+		sx := 1
+		sy := 1
+		if x1 > x2 {
+			sx = -1
+		}
+		if y1 > y2 {
+			sy = -1
+		}
+		err := dx - dy
+
+		x, y := x1, y1
+		for {
+			plot(x, y)
+
+			if x == x2 && y == y2 {
+				break
+			}
+
+			e2 := 2 * err
+			if e2 > -dy {
+				err -= dy
+				x += sx
+			}
+			if e2 < dx {
+				err += dx
+				y += sy
+			}
+		}
+	}
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,100 @@
+package geography
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// cell is a display position as (x, y)
+type cell [2]int
+
+// drawnCells lists the cells that hold something, row by row
+func drawnCells(display [][]string) []cell {
+	var cells []cell
+	for y, row := range display {
+		for x, ch := range row {
+			if ch != " " {
+				cells = append(cells, cell{x, y})
+			}
+		}
+	}
+	return cells
+}
+
+func TestDrawLine(t *testing.T) {
+	right := config.RadarWidth - 1
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		want           []cell
+	}{
+		{"single cell", 4, 4, 4, 4, []cell{{4, 4}}},
+		{"vertical", 5, 5, 5, 2, []cell{{5, 2}, {5, 3}, {5, 4}, {5, 5}}},
+		{"horizontal", 7, 1, 3, 1, []cell{{3, 1}, {4, 1}, {5, 1}, {6, 1}, {7, 1}}},
+		{"diagonal", 0, 0, 3, 3, []cell{{0, 0}, {1, 1}, {2, 2}, {3, 3}}},
+		{"shallow diagonal", 0, 0, 4, 2, []cell{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}}},
+		{"vertical off the top", 2, -5, 2, 1, []cell{{2, 0}, {2, 1}}},
+		{"horizontal off the right", right - 2, 3, right + 5, 3, []cell{{right - 2, 3}, {right - 1, 3}, {right, 3}}},
+		// Clamping the start to the edge would draw a 45° line from (0, 0)
+		{"diagonal off the left keeps its slope", -4, 0, 4, 4, []cell{{0, 2}, {1, 2}, {2, 3}, {3, 3}, {4, 4}}},
+		{"wholly above", 0, -3, 10, -1, nil},
+		{"wholly left", -5, -5, -1, 10, nil},
+		{"past the corner without crossing", -3, 1, 1, -3, nil},
+	}
+
+	style := lipgloss.NewStyle()
+	for _, tt := range tests {
+		display := blankDisplay()
+		drawLine(display, tt.x1, tt.y1, tt.x2, tt.y2, "x", &style, false)
+		if got := drawnCells(display); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: drew %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDrawLineSkipExisting(t *testing.T) {
+	style := lipgloss.NewStyle()
+	display := blankDisplay()
+	display[0][2] = "#"
+
+	drawLine(display, 0, 0, 4, 0, "x", &style, true)
+	if got := display[0][2]; got != "#" {
+		t.Errorf("existing cell = %q, want it kept as #", got)
+	}
+	if got := drawnCells(display); len(got) != 5 {
+		t.Errorf("drew %v, want the five cells from (0, 0) to (4, 0)", got)
+	}
+
+	drawLine(display, 0, 0, 4, 0, "x", &style, false)
+	if got := display[0][2]; got != "x" {
+		t.Errorf("existing cell without skipExisting = %q, want x", got)
+	}
+}
+
+func TestClipLine(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		want           [4]int
+		visible        bool
+	}{
+		{"inside", 1, 1, 8, 5, [4]int{1, 1, 8, 5}, true},
+		{"through both sides", -10, 2, 20, 2, [4]int{0, 2, 9, 2}, true},
+		{"crossing a corner", -2, 2, 2, -2, [4]int{0, 0, 0, 0}, true},
+		{"off the left keeps its slope", -4, 0, 4, 4, [4]int{0, 2, 4, 4}, true},
+		{"through the grid from far away", -1000, -1000, 1000, 1000, [4]int{0, 0, 9, 9}, true},
+		{"wholly below", 0, 12, 9, 15, [4]int{}, false},
+		{"past the corner", -3, 1, 1, -3, [4]int{}, false},
+	}
+
+	for _, tt := range tests {
+		x1, y1, x2, y2, visible := clipLine(tt.x1, tt.y1, tt.x2, tt.y2, 10, 10)
+		if visible != tt.visible || (visible && [4]int{x1, y1, x2, y2} != tt.want) {
+			t.Errorf("%s: clipLine = (%d, %d)-(%d, %d) %t, want %v %t", tt.name, x1, y1, x2, y2, visible, tt.want, tt.visible)
+		}
+	}
+}
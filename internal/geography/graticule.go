@@ -0,0 +1,113 @@
+package geography
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// graticuleSteps are the line spacings tried, in degrees, until no more than
+// graticuleMaxLines lines cross the view, so zoomed-out regions stay legible
+var graticuleSteps = []float64{1, 2, 5, 10}
+
+const graticuleMaxLines = 8
+
+// DrawGraticule overlays faint latitude and longitude lines at whole degrees,
+// labeled with their values along the left and bottom edges. Only empty cells
+// are drawn, so boundaries and markers stay visible and precipitation drawn
+// afterwards covers it.
+func DrawGraticule(display [][]string, proj Projection) {
+	if len(display) == 0 {
+		return
+	}
+	height, width := len(display), len(display[0])
+
+	north, west := proj.ToLatLon(0, 0)
+	south, east := proj.ToLatLon(width-1, height-1)
+	step := graticuleStep(east-west, north-south)
+
+	// The projection is flat, so each meridian is a column and each parallel
+	// a row
+	columns := map[int]float64{}
+	for i := math.Ceil(west / step); i*step <= east; i++ {
+		x, _ := proj.ToDisplay(proj.CenterLat, i*step)
+		columns[x] = i * step
+	}
+	rows := map[int]float64{}
+	for i := math.Ceil(south / step); i*step <= north; i++ {
+		_, y := proj.ToDisplay(i*step, proj.CenterLon)
+		rows[y] = i * step
+	}
+
+	lineStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("236"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	vertical, horizontal, crossing := lineStyle.Render("┊"), lineStyle.Render("┈"), lineStyle.Render("┼")
+
+	// drawn marks the cells the graticule owns, which labels may replace
+	drawn := make([][]bool, height)
+	for y := range display {
+		drawn[y] = make([]bool, width)
+		_, onRow := rows[y]
+		for x := range display[y] {
+			_, onColumn := columns[x]
+			if display[y][x] != " " || !(onRow || onColumn) {
+				continue
+			}
+			switch {
+			case onRow && onColumn:
+				display[y][x] = crossing
+			case onColumn:
+				display[y][x] = vertical
+			default:
+				display[y][x] = horizontal
+			}
+			drawn[y][x] = true
+		}
+	}
+
+	// Labels are drawn whole or not at all, where nothing else is drawn
+	label := func(x, y int, text string) {
+		chars := []rune(text)
+		for i := range chars {
+			if !inDisplay(display, x+i, y) || !(drawn[y][x+i] || display[y][x+i] == " ") {
+				return
+			}
+		}
+		for i, ch := range chars {
+			display[y][x+i] = labelStyle.Render(string(ch))
+		}
+	}
+	for y, lat := range rows {
+		label(0, y, formatDegrees(lat, "N", "S"))
+	}
+	for x, lon := range columns {
+		// Beside the line rather than over it, so the line stays visible
+		label(x+1, height-1, formatDegrees(lon, "E", "W"))
+	}
+}
+
+// graticuleStep picks the finest spacing that keeps the lines across the
+// wider of the view's spans under graticuleMaxLines (private helper)
+func graticuleStep(lonSpan, latSpan float64) float64 {
+	span := max(lonSpan, latSpan)
+	for _, step := range graticuleSteps {
+		if span/step <= graticuleMaxLines {
+			return step
+		}
+	}
+	return graticuleSteps[len(graticuleSteps)-1]
+}
+
+// formatDegrees labels a whole-degree line with its hemisphere, e.g. 105°W
+// (private helper)
+func formatDegrees(degrees float64, positive, negative string) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+	}
+	if degrees == 0 {
+		hemisphere = ""
+	}
+	return fmt.Sprintf("%.0f°%s", math.Abs(degrees), hemisphere)
+}
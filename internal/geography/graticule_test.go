@@ -0,0 +1,81 @@
+package geography
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// blankDisplay returns an empty radar grid
+func blankDisplay() [][]string {
+	display := make([][]string, config.RadarHeight)
+	for y := range display {
+		display[y] = make([]string, config.RadarWidth)
+		for x := range display[y] {
+			display[y][x] = " "
+		}
+	}
+	return display
+}
+
+func TestDrawGraticuleWholeDegrees(t *testing.T) {
+	display := blankDisplay()
+	DrawGraticule(display, denver)
+
+	// Denver's view spans 38.6-40.9°N and 107.5-102.6°W
+	for _, lon := range []float64{-107, -106, -105, -104, -103} {
+		x, _ := denver.ToDisplay(denver.CenterLat, lon)
+		if got := ansi.Strip(display[0][x]); got != "┊" {
+			t.Errorf("top of the %.0f° meridian = %q, want ┊", lon, got)
+		}
+	}
+	for _, lat := range []float64{39, 40} {
+		_, y := denver.ToDisplay(lat, denver.CenterLon)
+		row := ansi.Strip(strings.Join(display[y], ""))
+		if want := formatDegrees(lat, "N", "S"); !strings.HasPrefix(row, want) {
+			t.Errorf("row for %.0f°N = %q, want it labeled %s", lat, row, want)
+		}
+	}
+}
+
+func TestDrawGraticuleKeepsDrawnCells(t *testing.T) {
+	display := blankDisplay()
+	for y := range display {
+		for x := range display[y] {
+			if (x+y)%2 == 0 {
+				display[y][x] = "X"
+			}
+		}
+	}
+	DrawGraticule(display, denver)
+
+	for y := range display {
+		for x := range display[y] {
+			if (x+y)%2 == 0 && display[y][x] != "X" {
+				t.Fatalf("cell (%d, %d) was overwritten with %q", x, y, ansi.Strip(display[y][x]))
+			}
+		}
+	}
+}
+
+func TestGraticuleStep(t *testing.T) {
+	tests := []struct {
+		lonSpan, latSpan float64
+		want             float64
+	}{
+		{5, 2, 1},
+		{15, 9, 2},
+		{20, 12, 5},
+		{80, 40, 10},
+		{200, 100, 10},
+	}
+
+	for _, tt := range tests {
+		if got := graticuleStep(tt.lonSpan, tt.latSpan); got != tt.want {
+			t.Errorf("graticuleStep(%g, %g) = %g, want %g", tt.lonSpan, tt.latSpan, got, tt.want)
+		}
+	}
+}
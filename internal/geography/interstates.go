@@ -0,0 +1,27 @@
+package geography
+
+import (
+	_ "embed"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// interstatesGeoJSON holds the main Interstate Highways as LineStrings with
+// [lon, lat] coordinates, each traced through the cities and junctions it
+// links. That's coarse next to the real roads but finer than a grid cell in
+// the standard view.
+//
+//go:embed data/interstates.geojson
+var interstatesGeoJSON []byte
+
+// interstates is the Interstate Highways layer
+var interstates = &geoLayer{name: "interstates", data: interstatesGeoJSON}
+
+// interstateStyle sets highways apart from the gray borders
+var interstateStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("130"))
+
+// drawInterstates traces the Interstates in the view with double lines,
+// beneath the state borders
+func drawInterstates(display [][]string, proj Projection) {
+	drawPolylines(display, proj, interstates.polylines(), lineStyle{style: interstateStyle, horizontal: "═", vertical: "║", beneath: true})
+}
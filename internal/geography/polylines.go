@@ -0,0 +1,155 @@
+package geography
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// polyline is a line traced through lat/lon points, with its bounding box
+// so lines outside the view can be skipped without projecting them
+type polyline struct {
+	lats, lons     []float64
+	minLat, maxLat float64
+	minLon, maxLon float64
+}
+
+// geoLayer is an embedded GeoJSON file of lines and polygons, parsed into
+// polylines the first time it's drawn
+type geoLayer struct {
+	name  string
+	data  []byte
+	once  sync.Once
+	lines []polyline
+}
+
+// polylines parses the layer once (private helper)
+func (l *geoLayer) polylines() []polyline {
+	l.once.Do(func() {
+		var collection struct {
+			Features []struct {
+				Geometry struct {
+					Type        string          `json:"type"`
+					Coordinates json.RawMessage `json:"coordinates"`
+				} `json:"geometry"`
+			} `json:"features"`
+		}
+		if err := json.Unmarshal(l.data, &collection); err != nil {
+			log.Printf("Failed to parse %s: %v", l.name, err)
+			return
+		}
+
+		for _, feature := range collection.Features {
+			lines, err := geometryLines(feature.Geometry.Type, feature.Geometry.Coordinates)
+			if err != nil {
+				log.Printf("Skipping %s %s: %v", feature.Geometry.Type, l.name, err)
+				continue
+			}
+			for _, line := range lines {
+				if len(line) >= 2 {
+					l.lines = append(l.lines, newPolyline(line))
+				}
+			}
+		}
+	})
+	return l.lines
+}
+
+// geometryLines flattens a GeoJSON geometry's coordinates into the lines to
+// trace: every ring of a polygon, or the line itself (private helper)
+func geometryLines(kind string, coordinates json.RawMessage) ([][][]float64, error) {
+	var lines [][][]float64
+	switch kind {
+	case "LineString":
+		var line [][]float64
+		err := json.Unmarshal(coordinates, &line)
+		return append(lines, line), err
+	case "MultiLineString", "Polygon":
+		err := json.Unmarshal(coordinates, &lines)
+		return lines, err
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		err := json.Unmarshal(coordinates, &polygons)
+		for _, rings := range polygons {
+			lines = append(lines, rings...)
+		}
+		return lines, err
+	}
+	return nil, nil
+}
+
+// newPolyline converts [lon, lat] pairs to a polyline (private helper)
+func newPolyline(points [][]float64) polyline {
+	p := polyline{
+		minLat: math.Inf(1), maxLat: math.Inf(-1),
+		minLon: math.Inf(1), maxLon: math.Inf(-1),
+	}
+	for _, point := range points {
+		if len(point) < 2 {
+			continue
+		}
+		lon, lat := point[0], point[1]
+		p.lats = append(p.lats, lat)
+		p.lons = append(p.lons, lon)
+		p.minLat, p.maxLat = min(p.minLat, lat), max(p.maxLat, lat)
+		p.minLon, p.maxLon = min(p.minLon, lon), max(p.maxLon, lon)
+	}
+	return p
+}
+
+// latLonPolylines converts paths of [lat, lon] points, the order the
+// features written out in code use, to polylines (private helper)
+func latLonPolylines(paths ...[][]float64) []polyline {
+	lines := make([]polyline, 0, len(paths))
+	for _, path := range paths {
+		points := make([][]float64, len(path))
+		for i, point := range path {
+			points[i] = []float64{point[1], point[0]}
+		}
+		lines = append(lines, newPolyline(points))
+	}
+	return lines
+}
+
+// lineStyle is how drawPolylines traces its lines
+type lineStyle struct {
+	style lipgloss.Style
+	// horizontal is drawn for steps mostly across the grid and vertical for
+	// steps mostly down it; with no vertical, horizontal is used throughout
+	horizontal, vertical string
+	// beneath leaves cells that already hold something alone
+	beneath bool
+}
+
+// drawPolylines traces lines in the view in the given style, skipping those
+// entirely outside its corners
+func drawPolylines(display [][]string, proj Projection, lines []polyline, line lineStyle) {
+	north, west := proj.ToLatLon(-1, -1)
+	south, east := proj.ToLatLon(config.RadarWidth, config.RadarHeight)
+
+	horizontal, vertical := line.style.Render(line.horizontal), line.style.Render(line.vertical)
+	if line.vertical == "" {
+		vertical = horizontal
+	}
+	for _, l := range lines {
+		if len(l.lats) == 0 || l.maxLat < south || l.minLat > north || l.maxLon < west || l.minLon > east {
+			continue
+		}
+
+		x1, y1 := proj.ToDisplay(l.lats[0], l.lons[0])
+		for i := 1; i < len(l.lats); i++ {
+			x2, y2 := proj.ToDisplay(l.lats[i], l.lons[i])
+			glyph := horizontal
+			if absInt(x2-x1) < absInt(y2-y1) {
+				glyph = vertical
+			}
+			plotLine(display, x1, y1, x2, y2, glyph, line.beneath)
+			x1, y1 = x2, y2
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package geography
+
+import (
+	"math"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+func TestEmbeddedLayers(t *testing.T) {
+	for _, layer := range []*geoLayer{stateBorders, interstates} {
+		if len(layer.polylines()) == 0 {
+			t.Errorf("%s: no lines parsed", layer.name)
+		}
+	}
+
+	// The Interstates all run within the contiguous states
+	for _, line := range interstates.polylines() {
+		if line.minLat < 24 || line.maxLat > 49.5 || line.minLon < -125 || line.maxLon > -66 {
+			t.Errorf("interstate spanning %.1f-%.1f°N, %.1f-%.1f°E is out of place", line.minLat, line.maxLat, line.minLon, line.maxLon)
+		}
+	}
+}
+
+func TestDrawPolylinesClipping(t *testing.T) {
+	right := config.RadarWidth - 1
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		cells          int
+	}{
+		{"inside", 10, 10, 20, 15, 11},
+		{"crossing the right edge", 50, 10, 80, 25, right - 50 + 1},
+		{"crossing the bottom", 10, 20, 16, 38, config.RadarHeight - 20},
+		{"outside", 70, 5, 90, 40, 0},
+	}
+
+	for _, tt := range tests {
+		// Paths are lat/lon, so the cells are turned into the points on them
+		lat1, lon1 := denver.ToLatLon(tt.x1, tt.y1)
+		lat2, lon2 := denver.ToLatLon(tt.x2, tt.y2)
+
+		display := blankDisplay()
+		drawPolylines(display, denver, latLonPolylines([][]float64{{lat1, lon1}, {lat2, lon2}}), lineStyle{style: lipgloss.NewStyle(), horizontal: "~"})
+		cells := drawnCells(display)
+
+		if len(cells) != tt.cells {
+			t.Errorf("%s: drew %d cells, want %d", tt.name, len(cells), tt.cells)
+		}
+
+		// Every cell is on the original line, so nothing bends along an edge
+		for _, c := range cells {
+			along := float64(c[0]-tt.x1)/float64(tt.x2-tt.x1)*float64(tt.y2-tt.y1) + float64(tt.y1)
+			if math.Abs(float64(c[1])-along) > 1 {
+				t.Errorf("%s: cell %v is off the line", tt.name, c)
+			}
+		}
+	}
+}
+
+func TestDrawPolylinesBeneath(t *testing.T) {
+	lat1, lon1 := denver.ToLatLon(0, 10)
+	lat2, lon2 := denver.ToLatLon(config.RadarWidth-1, 10)
+	lines := latLonPolylines([][]float64{{lat1, lon1}, {lat2, lon2}})
+
+	for _, beneath := range []bool{false, true} {
+		display := blankDisplay()
+		display[10][5] = "x"
+		drawPolylines(display, denver, lines, lineStyle{style: lipgloss.NewStyle(), horizontal: "═", beneath: beneath})
+
+		want := "═"
+		if beneath {
+			want = "x"
+		}
+		if display[10][5] != want || display[10][6] != "═" {
+			t.Errorf("beneath %t: drew %q over x and %q beside it", beneath, display[10][5], display[10][6])
+		}
+	}
+}
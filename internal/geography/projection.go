@@ -0,0 +1,86 @@
+package geography
+
+import (
+	"math"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// Projection maps between lat/lon and display cells for a view centered on a
+// location. It is a flat equirectangular projection scaled in miles, which is
+// accurate enough over a few hundred miles.
+type Projection struct {
+	CenterLat float64
+	CenterLon float64
+	CenterX   int
+	CenterY   int
+	// MilesPerCellX and MilesPerCellY are the ground size of one cell
+	MilesPerCellX float64
+	MilesPerCellY float64
+}
+
+// NewProjection creates the standard radar view projection centered on
+// lat/lon at display cell centerX/centerY
+func NewProjection(lat, lon float64, centerX, centerY int) Projection {
+	return Projection{
+		CenterLat:     lat,
+		CenterLon:     lon,
+		CenterX:       centerX,
+		CenterY:       centerY,
+		MilesPerCellX: config.ViewWidthMiles / float64(config.RadarWidth),
+		MilesPerCellY: config.ViewHeightMiles / float64(config.RadarHeight),
+	}
+}
+
+// Zoomed returns the projection covering scale times as much ground on the
+// same grid, less for a scale under 1; zero or less leaves it unchanged
+func (p Projection) Zoomed(scale float64) Projection {
+	if scale > 0 {
+		p.MilesPerCellX *= scale
+		p.MilesPerCellY *= scale
+	}
+	return p
+}
+
+// milesPerDegreeLon is the length of one degree of longitude at the center
+func (p Projection) milesPerDegreeLon() float64 {
+	return weather.MilesPerDegreeLat * math.Cos(p.CenterLat*math.Pi/180)
+}
+
+// ToDisplay converts a lat/lon to display cell coordinates. Offsets truncate
+// towards the center, so every other cell covers one cell of ground while the
+// center cell covers two: one cell either side of the center point.
+func (p Projection) ToDisplay(lat, lon float64) (int, int) {
+	milesNorth := (lat - p.CenterLat) * weather.MilesPerDegreeLat
+	milesEast := (lon - p.CenterLon) * p.milesPerDegreeLon()
+
+	x := p.CenterX + int(milesEast/p.MilesPerCellX)
+	y := p.CenterY - int(milesNorth/p.MilesPerCellY)
+
+	return x, y
+}
+
+// ToLatLon converts a display cell back to the lat/lon at the middle of the
+// ground it covers, so ToDisplay(ToLatLon(x, y)) returns x, y
+func (p Projection) ToLatLon(x, y int) (float64, float64) {
+	milesEast := cellMiddle(x-p.CenterX) * p.MilesPerCellX
+	milesNorth := -cellMiddle(y-p.CenterY) * p.MilesPerCellY
+
+	lat := p.CenterLat + milesNorth/weather.MilesPerDegreeLat
+	lon := p.CenterLon + milesEast/p.milesPerDegreeLon()
+
+	return lat, lon
+}
+
+// cellMiddle returns the middle of the offset range that truncates to the
+// given cell offset, in cells (private helper)
+func cellMiddle(offset int) float64 {
+	switch {
+	case offset > 0:
+		return float64(offset) + 0.5
+	case offset < 0:
+		return float64(offset) - 0.5
+	}
+	return 0
+}
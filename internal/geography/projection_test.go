@@ -0,0 +1,56 @@
+package geography
+
+import (
+	"testing"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// denver is a view centered on downtown Denver in the middle of the grid
+var denver = NewProjection(39.7392, -104.9903, config.RadarWidth/2, config.RadarHeight/2)
+
+func TestToDisplayCenter(t *testing.T) {
+	x, y := denver.ToDisplay(denver.CenterLat, denver.CenterLon)
+	if x != config.RadarWidth/2 || y != config.RadarHeight/2 {
+		t.Errorf("center = (%d, %d), want (%d, %d)", x, y, config.RadarWidth/2, config.RadarHeight/2)
+	}
+
+	lat, lon := denver.ToLatLon(denver.CenterX, denver.CenterY)
+	if lat != denver.CenterLat || lon != denver.CenterLon {
+		t.Errorf("ToLatLon(center) = %f, %f, want %f, %f", lat, lon, denver.CenterLat, denver.CenterLon)
+	}
+}
+
+func TestToDisplayLandmarks(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		x, y     int
+	}{
+		{"Boulder", 40.0150, -105.2705, 27, 12},
+		{"Fort Collins", 40.5853, -105.0844, 29, 4},
+		{"Colorado Springs", 38.8339, -104.8214, 32, 27},
+		{"Limon", 39.2639, -103.6922, 46, 21},
+	}
+
+	for _, tt := range tests {
+		x, y := denver.ToDisplay(tt.lat, tt.lon)
+		if x != tt.x || y != tt.y {
+			t.Errorf("%s = (%d, %d), want (%d, %d)", tt.name, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestToLatLonRoundTrip(t *testing.T) {
+	for _, scale := range []float64{0.25, 1, 4} {
+		proj := denver.Zoomed(scale)
+		for y := 0; y < config.RadarHeight; y++ {
+			for x := 0; x < config.RadarWidth; x++ {
+				lat, lon := proj.ToLatLon(x, y)
+				if gotX, gotY := proj.ToDisplay(lat, lon); gotX != x || gotY != y {
+					t.Errorf("scale %g: ToDisplay(ToLatLon(%d, %d)) = (%d, %d)", scale, x, y, gotX, gotY)
+				}
+			}
+		}
+	}
+}
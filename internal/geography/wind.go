@@ -0,0 +1,83 @@
+package geography
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// Spacing between wind glyphs, in display cells: every 10th column and 5th
+// row, about 42 miles across and 25 miles down in the standard view
+const (
+	windSpacingX = 10
+	windSpacingY = 5
+)
+
+// windArrows maps the compass point a wind blows from to an arrow pointing
+// where it blows toward
+var windArrows = map[string]string{
+	"N": "↓", "NNE": "↓", "NE": "↙", "ENE": "←",
+	"E": "←", "ESE": "←", "SE": "↖", "SSE": "↑",
+	"S": "↑", "SSW": "↑", "SW": "↗", "WSW": "→",
+	"W": "→", "WNW": "→", "NW": "↘", "NNW": "↓",
+}
+
+// DrawWindBarbs draws forecast wind arrows at regular intervals, each taken
+// from the nearest sampled grid point. Only empty cells are drawn so the
+// overlay sits under precipitation drawn afterwards.
+func DrawWindBarbs(display [][]string, proj Projection, winds []weather.WindVector) {
+	if len(winds) == 0 || len(display) == 0 {
+		return
+	}
+
+	type sample struct {
+		x, y int
+		wind weather.WindVector
+	}
+
+	samples := make([]sample, len(winds))
+	for i, w := range winds {
+		x, y := proj.ToDisplay(w.Lat, w.Lon)
+		samples[i] = sample{x: x, y: y, wind: w}
+	}
+
+	for y := windSpacingY / 2; y < len(display); y += windSpacingY {
+		for x := windSpacingX / 2; x < len(display[y]); x += windSpacingX {
+			if display[y][x] != " " {
+				continue
+			}
+
+			nearest := samples[0]
+			best := -1
+			for _, s := range samples {
+				d := (s.x-x)*(s.x-x) + (s.y-y)*(s.y-y)
+				if best < 0 || d < best {
+					best = d
+					nearest = s
+				}
+			}
+
+			display[y][x] = windGlyph(nearest.wind)
+		}
+	}
+}
+
+// windGlyph renders an arrow colored by wind speed (private helper)
+func windGlyph(w weather.WindVector) string {
+	arrow, ok := windArrows[w.Direction]
+	if !ok || w.SpeedMPH < 3 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("○")
+	}
+
+	color := lipgloss.Color("244")
+	switch {
+	case w.SpeedMPH >= 40:
+		color = lipgloss.Color("201")
+	case w.SpeedMPH >= 25:
+		color = lipgloss.Color("177")
+	case w.SpeedMPH >= 15:
+		color = lipgloss.Color("147")
+	}
+
+	return lipgloss.NewStyle().Foreground(color).Render(arrow)
+}
@@ -0,0 +1,158 @@
+// Package httpclient builds the HTTP clients used for every external request.
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Timeouts for each kind of request, applied to every attempt
+const (
+	// APITimeout is for the NWS's small JSON answers
+	APITimeout = 5 * time.Second
+	// ServiceTimeout is for geocoding, the RainViewer index and tiles, and
+	// single product images
+	ServiceTimeout = 10 * time.Second
+	// LoopTimeout is for the large WMS images behind loops and replays
+	LoopTimeout = 30 * time.Second
+)
+
+// Version is reported in the User-Agent. Release builds can set it with
+// -ldflags "-X github.com/N-Erickson/termidar/internal/httpclient.Version=v1.2.3";
+// otherwise it's the module version go stamped into the binary.
+var Version = ""
+
+// contact is where the User-Agent points service operators, as the NWS asks
+const contact = "https://github.com/N-Erickson/termidar"
+
+// Fetch retries transient failures this many more times, backing off from
+// RetryDelay
+const (
+	Retries    = 3
+	RetryDelay = 250 * time.Millisecond
+)
+
+// Transport is shared by every client created with New. Replace it before
+// any requests are made to redirect traffic, e.g. to the offline fixtures.
+var Transport http.RoundTripper = newTransport()
+
+// newTransport is the default transport, keeping enough idle connections for
+// a load's concurrent tile downloads from one host (private helper)
+func newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 8
+	return transport
+}
+
+var (
+	clientsMu sync.Mutex
+	clients   = map[time.Duration]*http.Client{}
+)
+
+// New returns the shared client with the given timeout. Every client sends
+// the User-Agent and goes through the shared Transport, so connections are
+// reused across the whole app.
+func New(timeout time.Duration) *http.Client {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	client, ok := clients[timeout]
+	if !ok {
+		client = &http.Client{Timeout: timeout, Transport: userAgentTransport{}}
+		clients[timeout] = client
+	}
+	return client
+}
+
+// UserAgent identifies termidar to the services it calls, e.g.
+// "termidar/v1.2.3 (https://github.com/N-Erickson/termidar)"
+func UserAgent() string {
+	version := Version
+	if version == "" {
+		version = "dev"
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			version = info.Main.Version
+		}
+	}
+	return "termidar/" + version + " (" + contact + ")"
+}
+
+// userAgentTransport sets the User-Agent on requests that don't have one and
+// passes them to Transport, looked up per request so replacing it takes
+// effect for existing clients too
+type userAgentTransport struct{}
+
+func (userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		// RoundTrippers mustn't modify the request they're given
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", UserAgent())
+	}
+	return Transport.RoundTrip(req)
+}
+
+// Get issues a GET with client that is abandoned when ctx is done, on top
+// of the client's own per-request timeout
+func Get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// Fetch is Get with the default retries, for every request to a service
+// that can fail transiently
+func Fetch(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	return GetRetry(ctx, client, url, Retries, RetryDelay)
+}
+
+// GetRetry is Get with up to retries further attempts when the request fails
+// outright or the server answers 5xx or 429. The wait doubles from delay
+// after each attempt, with jitter so clients that failed together don't
+// retry together. The last response is returned as is, so callers still
+// check its status. It stops retrying once ctx is done.
+func GetRetry(ctx context.Context, client *http.Client, url string, retries int, delay time.Duration) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := Get(ctx, client, url)
+		if attempt >= retries || ctx.Err() != nil || !retryable(resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff(delay, attempt)):
+		}
+	}
+}
+
+// backoff is the wait before retry number attempt+1: delay doubled for each
+// earlier attempt, of which a random half is taken off (private helper)
+func backoff(delay time.Duration, attempt int) time.Duration {
+	wait := delay << attempt
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// retryable reports whether a failed request is worth trying again: other
+// 4xx answers and hosts that don't exist won't change on a retry (private
+// helper)
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		var dnsErr *net.DNSError
+		return !errors.As(err, &dnsErr) || !dnsErr.IsNotFound
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
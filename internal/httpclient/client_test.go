@@ -0,0 +1,84 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		want     int
+		requests int
+	}{
+		{"ok first time", []int{200}, 200, 1},
+		{"recovers from 5xx", []int{503, 502, 200}, 200, 3},
+		{"rate limited", []int{429, 200}, 200, 2},
+		{"gives up after retries", []int{500, 500, 500, 500, 200}, 500, 4},
+		{"not found isn't retried", []int{404, 200}, 404, 1},
+		{"bad request isn't retried", []int{400, 200}, 400, 1},
+	}
+
+	for _, tt := range tests {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.statuses[min(requests, len(tt.statuses)-1)])
+			requests++
+		}))
+
+		resp, err := GetRetry(context.Background(), server.Client(), server.URL, 3, time.Millisecond)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode != tt.want || requests != tt.requests {
+				t.Errorf("%s: status %d after %d requests, want %d after %d", tt.name, resp.StatusCode, requests, tt.want, tt.requests)
+			}
+		}
+		server.Close()
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := range 4 {
+		full := 100 * time.Millisecond << attempt
+		for range 50 {
+			if wait := backoff(100*time.Millisecond, attempt); wait < full/2 || wait > full {
+				t.Fatalf("backoff(100ms, %d) = %v, want between %v and %v", attempt, wait, full/2, full)
+			}
+		}
+	}
+	if wait := backoff(0, 2); wait != 0 {
+		t.Errorf("backoff(0, 2) = %v, want 0", wait)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("User-Agent")
+	}))
+	defer server.Close()
+
+	saved := Transport
+	Transport = server.Client().Transport
+	defer func() { Transport = saved }()
+
+	resp, err := Get(context.Background(), New(APITimeout), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got != UserAgent() || !strings.HasPrefix(got, "termidar/") || !strings.Contains(got, contact) {
+		t.Errorf("User-Agent = %q, want %q", got, UserAgent())
+	}
+	if New(APITimeout) != New(APITimeout) {
+		t.Errorf("New should reuse the client for a timeout")
+	}
+}
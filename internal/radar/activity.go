@@ -0,0 +1,132 @@
+package radar
+
+import "math"
+
+// MaxIntensity is the top of the intensity scale frames are decoded to
+const MaxIntensity = 10
+
+// DefaultActivityWindow is how many of the newest frames FrameActivity
+// considers, about half an hour of a default loop
+const DefaultActivityWindow = 6
+
+// HasEchoes reports whether any cell of a grid is nonzero, for setting
+// Frame.HasPrecip on frames that weren't decoded from a reflectivity image
+func HasEchoes(data [][]int) bool {
+	for _, row := range data {
+		for _, v := range row {
+			if v != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FrameCoverage returns the intensity-weighted share of a frame that is
+// precipitating, from 0 (dry) to 1 (every cell at maximum intensity)
+func FrameCoverage(frame Frame) float64 {
+	if !frame.HasPrecip {
+		return 0
+	}
+	var sum, cells int
+	for _, row := range frame.Data {
+		for _, intensity := range row {
+			sum += max(intensity, 0)
+			cells++
+		}
+	}
+	if cells == 0 {
+		return 0
+	}
+	return float64(sum) / float64(cells*MaxIntensity)
+}
+
+// FrameChange returns how much precipitation moved or changed between two
+// frames, from 0 (identical) to 1 (every cell swung across the full scale).
+// Cells outside either frame's grid are ignored.
+func FrameChange(prev, next Frame) float64 {
+	if !prev.HasPrecip && !next.HasPrecip {
+		return 0
+	}
+	var sum float64
+	var cells int
+	for y := 0; y < len(prev.Data) && y < len(next.Data); y++ {
+		for x := 0; x < len(prev.Data[y]) && x < len(next.Data[y]); x++ {
+			sum += math.Abs(float64(next.Data[y][x] - prev.Data[y][x]))
+			cells++
+		}
+	}
+	if cells == 0 {
+		return 0
+	}
+	return sum / float64(cells*MaxIntensity)
+}
+
+// FrameActivity scores the newest DefaultActivityWindow frames; see
+// FrameActivityWindow
+func FrameActivity(frames []Frame) float64 {
+	return FrameActivityWindow(frames, DefaultActivityWindow)
+}
+
+// FrameActivityWindow scores how active the newest window frames are, from 0
+// (dry and still) to 1. It averages the mean coverage with the mean change
+// between consecutive frames, so both widespread rain and a fast-moving
+// storm register. A window of zero or less, or larger than the loop, uses
+// every frame.
+func FrameActivityWindow(frames []Frame, window int) float64 {
+	if window > 0 && window < len(frames) {
+		frames = frames[len(frames)-window:]
+	}
+	if len(frames) == 0 {
+		return 0
+	}
+
+	var coverage float64
+	for _, frame := range frames {
+		coverage += FrameCoverage(frame)
+	}
+	coverage /= float64(len(frames))
+
+	var change float64
+	if len(frames) > 1 {
+		for i := 1; i < len(frames); i++ {
+			change += FrameChange(frames[i-1], frames[i])
+		}
+		change /= float64(len(frames) - 1)
+	}
+
+	return (coverage + change) / 2
+}
+
+// FrameDiff returns next minus prev cell by cell: positive where
+// precipitation developed or strengthened, negative where it weakened or
+// moved off. The grid covers the overlap of the two frames.
+func FrameDiff(prev, next Frame) [][]int {
+	rows := min(len(prev.Data), len(next.Data))
+	diff := make([][]int, rows)
+	for y := range diff {
+		cols := min(len(prev.Data[y]), len(next.Data[y]))
+		diff[y] = make([]int, cols)
+		for x := range diff[y] {
+			diff[y][x] = next.Data[y][x] - prev.Data[y][x]
+		}
+	}
+	return diff
+}
+
+// BlendFrames interpolates each cell's intensity a fraction t of the way from
+// prev to next, rounding to the nearest level, for drawing in-between steps
+// of a loop. The grid covers the overlap of the two frames.
+func BlendFrames(prev, next Frame, t float64) [][]int {
+	rows := min(len(prev.Data), len(next.Data))
+	blend := make([][]int, rows)
+	for y := range blend {
+		cols := min(len(prev.Data[y]), len(next.Data[y]))
+		blend[y] = make([]int, cols)
+		for x := range blend[y] {
+			from, to := prev.Data[y][x], next.Data[y][x]
+			blend[y][x] = int(math.Round(float64(from) + float64(to-from)*t))
+		}
+	}
+	return blend
+}
@@ -0,0 +1,132 @@
+package radar
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// grid builds a frame from rows of intensities, setting HasPrecip the way
+// decoded frames do
+func grid(rows ...[]int) Frame {
+	return Frame{Data: rows, HasPrecip: HasEchoes(rows)}
+}
+
+// near reports whether two scores match to within rounding
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-9
+}
+
+func TestFrameCoverage(t *testing.T) {
+	tests := []struct {
+		name  string
+		frame Frame
+		want  float64
+	}{
+		{"empty", Frame{}, 0},
+		{"dry", grid([]int{0, 0}, []int{0, 0}), 0},
+		{"half", grid([]int{10, 0}, []int{5, 5}), 0.5},
+		{"full", grid([]int{10, 10}, []int{10, 10}), 1},
+		{"negative cells count as dry", grid([]int{-5, 10}), 0.5},
+		{"not flagged as precipitating", Frame{Data: [][]int{{10, 10}}}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := FrameCoverage(tt.frame); !near(got, tt.want) {
+			t.Errorf("%s: FrameCoverage = %f, want %f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFrameChange(t *testing.T) {
+	tests := []struct {
+		name       string
+		prev, next Frame
+		want       float64
+	}{
+		{"both empty", Frame{}, Frame{}, 0},
+		{"both dry", grid([]int{0, 0}), grid([]int{0, 0}), 0},
+		{"identical", grid([]int{10, 5}), grid([]int{10, 5}), 0},
+		{"developing", grid([]int{0, 0}, []int{0, 0}), grid([]int{10, 0}, []int{0, 0}), 0.25},
+		{"moved across", grid([]int{10, 0}), grid([]int{0, 10}), 1},
+		{"mismatched sizes use the overlap", grid([]int{10, 0}, []int{10, 10}), grid([]int{0, 0, 10}), 0.5},
+		{"no overlap", grid([]int{10}), Frame{HasPrecip: true}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := FrameChange(tt.prev, tt.next); !near(got, tt.want) {
+			t.Errorf("%s: FrameChange = %f, want %f", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFrameActivityOrdering(t *testing.T) {
+	dry := []Frame{grid([]int{0, 0}), grid([]int{0, 0}), grid([]int{0, 0})}
+	stationary := []Frame{grid([]int{10, 0}), grid([]int{10, 0}), grid([]int{10, 0})}
+	moving := []Frame{grid([]int{10, 0}), grid([]int{0, 10}), grid([]int{10, 0})}
+
+	dryScore, stationaryScore, movingScore := FrameActivity(dry), FrameActivity(stationary), FrameActivity(moving)
+	if !near(dryScore, 0) || !near(stationaryScore, 0.25) || !near(movingScore, 0.75) {
+		t.Errorf("scores = %f, %f, %f, want 0, 0.25, 0.75", dryScore, stationaryScore, movingScore)
+	}
+	if !(dryScore < stationaryScore && stationaryScore < movingScore) {
+		t.Errorf("a moving storm should score above a stationary one, and both above a dry loop")
+	}
+}
+
+func TestFrameActivityWindow(t *testing.T) {
+	full := grid([]int{10, 10})
+	dry := grid([]int{0, 0})
+	frames := []Frame{full, dry, dry}
+
+	tests := []struct {
+		name   string
+		frames []Frame
+		window int
+		want   float64
+	}{
+		{"no frames", nil, 3, 0},
+		{"newest frames only", frames, 2, 0},
+		{"single newest frame", []Frame{dry, full}, 1, 0.5},
+		// Coverage averages 1/3 and the one change out of two is total
+		{"zero window uses every frame", frames, 0, (1.0/3 + 0.5) / 2},
+		{"window beyond the loop uses every frame", frames, 10, (1.0/3 + 0.5) / 2},
+	}
+
+	for _, tt := range tests {
+		if got := FrameActivityWindow(tt.frames, tt.window); !near(got, tt.want) {
+			t.Errorf("%s: FrameActivityWindow = %f, want %f", tt.name, got, tt.want)
+		}
+	}
+
+	// FrameActivity ignores anything older than DefaultActivityWindow
+	loop := []Frame{full}
+	for range DefaultActivityWindow {
+		loop = append(loop, dry)
+	}
+	if got := FrameActivity(loop); got != 0 {
+		t.Errorf("FrameActivity with the only rain outside the window = %f, want 0", got)
+	}
+}
+
+func TestBlendFrames(t *testing.T) {
+	prev := grid([]int{0, 10, 4}, []int{8, 8, 8})
+	next := grid([]int{10, 0, 4}, []int{0, 8})
+
+	tests := []struct {
+		name     string
+		fraction float64
+		want     [][]int
+	}{
+		{"start is the older frame", 0, [][]int{{0, 10, 4}, {8, 8}}},
+		{"end is the newer frame", 1, [][]int{{10, 0, 4}, {0, 8}}},
+		{"halfway", 0.5, [][]int{{5, 5, 4}, {4, 8}}},
+		{"rounds to the nearest level", 0.25, [][]int{{3, 8, 4}, {6, 8}}},
+	}
+
+	for _, tt := range tests {
+		if got := BlendFrames(prev, next, tt.fraction); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%s: BlendFrames = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
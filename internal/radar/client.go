@@ -1,7 +1,9 @@
 package radar
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"image"
 	_ "image/jpeg"
@@ -10,24 +12,81 @@ import (
 	"log"
 	"math"
 	"net/http"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/httpclient"
 	"github.com/N-Erickson/termidar/internal/weather"
 )
 
 // Data represents radar data with frames and metadata
 type Data struct {
-	Frames      []Frame
+	Frames []Frame
+	// Lat and Lon are the center of the view, which is the searched location
+	// unless Options.Center moved it
+	Lat float64
+	Lon float64
+	// HomeLat and HomeLon are the searched location itself
+	HomeLat     float64
+	HomeLon     float64
 	Location    string
 	Station     string
 	LastUpdated time.Time
 	IsRealData  bool
-	Temperature int
-	Conditions  string
-	Alerts      []weather.Alert
+	// Celsius is the observed temperature as reported, nil when the
+	// observation didn't include one
+	Celsius    *float64
+	Conditions string
+	// Humidity (percent), DewpointC and PressurePa (pascals) come from the
+	// same observation, each nil when it wasn't reported
+	Humidity   *float64
+	DewpointC  *float64
+	PressurePa *float64
+	// ObservationStation is the station current conditions came from
+	ObservationStation string
+	// Geocoder is the provider that resolved the location
+	Geocoder string
+	// Scale is how many times the standard view's ground the frames cover:
+	// 1 for a local view, RegionScale for a ZIP prefix region, times
+	// Options.Zoom
+	Scale  float64
+	Alerts []weather.Alert
+	// FetchErrors records the NWS requests that failed; the display carries
+	// on without their data
+	FetchErrors FetchErrors
+	// Secondary holds an extra product fetched at the same times as Frames,
+	// index for index, when Options.SecondaryProduct is set
+	Secondary []Frame
+	// Wind holds the forecast wind grid when Options.Wind is set
+	Wind []weather.WindVector
+	// Hourly holds the hourly forecast when Options.Hourly is set and the
+	// NWS had one
+	Hourly []weather.HourlyPeriod
+	// Winter holds the snow depth and snowfall forecast, fetched only when
+	// weather.WinterLikely; empty the rest of the year
+	Winter weather.Winter
+}
+
+// FetchErrors holds why current conditions or alerts couldn't be fetched, nil
+// for each that arrived
+type FetchErrors struct {
+	Conditions error
+	Alerts     error
+}
+
+// Err combines the failures into one error, nil if there were none
+func (e FetchErrors) Err() error {
+	var errs []error
+	if e.Conditions != nil {
+		errs = append(errs, fmt.Errorf("current conditions: %w", e.Conditions))
+	}
+	if e.Alerts != nil {
+		errs = append(errs, fmt.Errorf("alerts: %w", e.Alerts))
+	}
+	return errors.Join(errs...)
 }
 
 // Frame represents a single radar frame
@@ -35,8 +94,26 @@ type Frame struct {
 	Data      [][]int
 	Timestamp time.Time
 	Product   string
+	// Source names the service that supplied the frame
+	Source string
+	// Overlay is the station whose own scan was merged over the frame within
+	// its range, empty when there is none
+	Overlay string
+	// HasPrecip is set when the decoder found any precipitation, so a
+	// blank map can be told apart from a failed load and dry frames can be
+	// skipped without scanning them. Frames built any other way should set
+	// it with HasEchoes.
+	HasPrecip bool
 }
 
+// Radar data sources frames can come from
+const (
+	SourceRainViewer = "RainViewer"
+	SourceIowaState  = "Iowa State Mesonet"
+	SourceNWS        = "NWS MRMS"
+	SourceSimulated  = "Simulated"
+)
+
 // Messages for tea.Cmd communication
 type LoadedMsg struct {
 	Radar Data
@@ -46,112 +123,397 @@ type ErrorMsg struct {
 	Err error
 }
 
-// LoadData loads radar data for a given ZIP code
-func LoadData(zipCode string) tea.Cmd {
+// Options controls what LoadData fetches beyond the default reflectivity loop
+type Options struct {
+	// SecondaryProduct is fetched alongside the primary frames when set
+	SecondaryProduct string
+	// Wind fetches the forecast wind grid for the overlay
+	Wind bool
+	// Hourly fetches the next hours of the hourly forecast for the searched
+	// location
+	Hourly bool
+	// Geocoder resolves the query; weather.DefaultGeocoder is used when nil
+	Geocoder weather.Geocoder
+	// ObservationStations is how many stations to try for current
+	// conditions; zero means only the nearest
+	ObservationStations int
+	// ReplayTime fetches an archived loop centered on this time instead of
+	// the latest frames when set
+	ReplayTime time.Time
+	// FrameInterval spaces the loop's frames; zero uses the archive's five
+	// minutes
+	FrameInterval time.Duration
+	// Center pans the view to another point; conditions and alerts still
+	// come from the searched location
+	Center *weather.Location
+	// Source is a custom WMS URL template (see SourceTemplate) used instead
+	// of RainViewer and Iowa State when set
+	Source string
+	// ForceSource fetches the latest loop from only this config.RadarSource
+	// value, failing rather than falling back, when set
+	ForceSource string
+	// Timeout bounds the whole load; zero means no overall limit beyond each
+	// request's own
+	Timeout time.Duration
+	// RainViewerRetries retries a failed RainViewer index request this many
+	// times before moving on to the next source
+	RainViewerRetries int
+	// Zoom multiplies the ground the view covers: under 1 zooms in, over 1
+	// out; zero is the standard view
+	Zoom float64
+	// StationOverlay merges the nearest station's sharper reflectivity over
+	// the latest loop within its range, at the cost of a second download
+	// per frame
+	StationOverlay bool
+}
+
+// ErrTimeout is returned when a load runs past Options.Timeout
+var ErrTimeout = errors.New("timed out")
+
+// rainViewerInterval is the spacing of RainViewer's past frames
+const rainViewerInterval = 10 * time.Minute
+
+// rainViewerRetryDelay is the first backoff between retries of the RainViewer
+// index, which is small enough that a quick second try beats the slower
+// fallback
+const rainViewerRetryDelay = 500 * time.Millisecond
+
+// rainViewerZoom is the tile zoom level of a standard view
+const rainViewerZoom = 7
+
+// RegionScale is how much further a three-digit ZIP prefix's region view is
+// zoomed out than a local view
+const RegionScale = 3.0
+
+// LoadData loads radar data for a given ZIP code, giving up with ErrTimeout
+// once opts.Timeout has passed. The command's message is a ProgressMsg
+// while the load runs, whose Next command waits for the following one, and
+// finally a LoadedMsg or ErrorMsg; Await skips straight to that.
+func LoadData(zipCode string, opts Options) tea.Cmd {
 	return func() tea.Msg {
-		// Create a custom logger that discards output during loading
-		// This prevents console spam from interfering with the display
-		oldOutput := log.Writer()
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if opts.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), opts.Timeout)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
+		}
+
+		load := &pendingLoad{
+			ctx:      ctx,
+			cancel:   cancel,
+			query:    zipCode,
+			timeout:  opts.Timeout,
+			progress: make(chan ProgressMsg, 1),
+			result:   make(chan tea.Msg, 1),
+		}
+		go func() {
+			defer cancel()
+			load.result <- loadData(context.WithValue(ctx, progressKey{}, load), zipCode, opts)
+		}()
+
+		return load.wait()
+	}
+}
+
+// Loads that overlap, including ones abandoned at their deadline, share one
+// silenced log; output is restored when the last of them finishes
+var (
+	silenceMu    sync.Mutex
+	silenceDepth int
+	silenceSaved io.Writer
+)
+
+// silenceLogs discards log output until the returned restore func is called
+func silenceLogs() func() {
+	silenceMu.Lock()
+	defer silenceMu.Unlock()
+	if silenceDepth == 0 {
+		silenceSaved = log.Writer()
 		log.SetOutput(io.Discard)
-		defer log.SetOutput(oldOutput)
+	}
+	silenceDepth++
+
+	return func() {
+		silenceMu.Lock()
+		defer silenceMu.Unlock()
+		silenceDepth--
+		if silenceDepth == 0 {
+			log.SetOutput(silenceSaved)
+		}
+	}
+}
+
+// loadData does the work of LoadData, stopping early once ctx is done
+func loadData(ctx context.Context, zipCode string, opts Options) tea.Msg {
+	// Discard log output during loading
+	// This prevents console spam from interfering with the display
+	defer silenceLogs()()
 
-		lat, lon, city, state, err := weather.GeocodeZip(zipCode)
+	geocoder := opts.Geocoder
+	if geocoder == nil {
+		geocoder = weather.DefaultGeocoder
+	}
+
+	source := iowaStateN0R
+	if opts.Source != "" {
+		custom, err := ParseSourceTemplate(opts.Source)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to geocode ZIP: %w", err)}
+			return ErrorMsg{Err: err}
 		}
+		source = custom
+	}
+
+	reportStage(ctx, StageLocating)
 
-		station, err := weather.GetNearestRadarStation(lat, lon)
+	// A ZIP prefix is a zoomed-out region, and anything but a ZIP code is
+	// a place name
+	var loc weather.Location
+	scale := 1.0
+	if weather.IsZipPrefix(zipCode) {
+		region, err := weather.GeocodeZipPrefix(zipCode)
 		if err != nil {
-			return ErrorMsg{Err: fmt.Errorf("failed to get radar station: %w", err)}
+			return ErrorMsg{Err: err}
+		}
+		loc, scale = region, RegionScale
+	} else {
+		query, kind := zipCode, "ZIP"
+		if !weather.IsZipCode(zipCode) {
+			place, err := weather.PlaceQuery(zipCode)
+			if err != nil {
+				return ErrorMsg{Err: err}
+			}
+			query, kind = place, "place"
 		}
 
-		temperature, conditions := weather.FetchCurrentConditions(lat, lon)
-		alerts := weather.FetchAlerts(lat, lon)
+		found, err := geocoder.Geocode(ctx, query)
+		if err != nil {
+			return ErrorMsg{Err: fmt.Errorf("failed to geocode %s: %w", kind, err)}
+		}
+		loc = found
+	}
+	if opts.Zoom > 0 {
+		scale *= opts.Zoom
+	}
+	source = source.Scaled(scale)
+	lat, lon := loc.Lat, loc.Lon
+	if opts.Center != nil {
+		lat, lon = opts.Center.Lat, opts.Center.Lon
+	}
+
+	reportStage(ctx, StageStation)
+	station, err := weather.GetNearestRadarStation(lat, lon)
+	if err != nil {
+		return ErrorMsg{Err: fmt.Errorf("failed to get radar station: %w", err)}
+	}
 
-		frames, isRealData, err := fetchRealRadarData(station, lat, lon)
+	reportStage(ctx, StageConditions)
+	// Missing conditions or alerts don't stop the radar; the errors are kept
+	// for callers that need to know, such as the --json report
+	obs, conditionsErr := weather.FetchObservation(ctx, loc.Lat, loc.Lon, opts.ObservationStations)
+	if conditionsErr != nil {
+		log.Printf("No current conditions: %v", conditionsErr)
+		if errors.Is(conditionsErr, weather.ErrNoTemperature) {
+			// The stations answered; they just had nothing to say
+			conditionsErr = nil
+		}
+	}
+	alerts, alertsErr := weather.FetchActiveAlerts(ctx, loc.Lat, loc.Lon)
+	if alertsErr != nil {
+		log.Printf("Failed to fetch weather alerts: %v", alertsErr)
+	}
+
+	reportStage(ctx, StageFrames)
+
+	// One count covers the whole loop and the secondary product, however
+	// the loop ends up being fetched
+	planFrames(ctx, plannedFrames(opts))
+
+	var frames []Frame
+	var isRealData bool
+	if !opts.ReplayTime.IsZero() {
+		// Simulated frames would be misleading for a past storm, so a
+		// replay with no archive data is an error
+		frames, err = fetchReplayFrames(ctx, source, station, lat, lon, opts.ReplayTime, opts.FrameInterval)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+		isRealData = true
+	} else if opts.ForceSource != "" {
+		frames, isRealData, err = fetchForcedFrames(ctx, opts.ForceSource, station, lat, lon, scale, opts.FrameInterval, opts.RainViewerRetries)
+		if err != nil {
+			return ErrorMsg{Err: err}
+		}
+	} else {
+		frames, isRealData, err = fetchRealRadarData(ctx, source, opts.Source != "", station, lat, lon, scale, opts.FrameInterval, opts.RainViewerRetries)
 		if err != nil {
 			frames = generateRadarFrames(station, config.MaxFrames)
 			isRealData = false
+		} else if opts.StationOverlay {
+			frames = overlayStation(ctx, frames, station, lat, lon, scale)
 		}
+	}
+
+	framesReached(ctx, config.MaxFrames)
 
-		location := fmt.Sprintf("%s, %s", city, state)
-
-		return LoadedMsg{
-			Radar: Data{
-				Frames:      frames,
-				Location:    location,
-				Station:     station,
-				LastUpdated: time.Now(),
-				IsRealData:  isRealData,
-				Temperature: temperature,
-				Conditions:  conditions,
-				Alerts:      alerts,
-			},
+	var secondary []Frame
+	if opts.SecondaryProduct != "" && isRealData {
+		times := make([]time.Time, len(frames))
+		for i, frame := range frames {
+			times[i] = frame.Timestamp
 		}
+		secondary = fetchProductFrames(ctx, station, opts.SecondaryProduct, lat, lon, scale, times)
 	}
-}
 
-func fetchRealRadarData(station string, lat, lon float64) ([]Frame, bool, error) {
-	client := &http.Client{Timeout: 30 * time.Second}
-	frames := []Frame{}
+	reportStage(ctx, StageProcessing)
 
-	// First try RainViewer
-	frames, err := fetchFromRainViewer(lat, lon)
-	if err == nil && len(frames) > 0 {
-		log.Printf("Successfully fetched %d frames from RainViewer", len(frames))
-		return frames, true, nil
+	var wind []weather.WindVector
+	if opts.Wind {
+		wind = weather.FetchWindGrid(ctx, lat, lon)
 	}
 
-	// Fallback to Iowa State University
-	baseTime := time.Now().UTC()
+	var hourly []weather.HourlyPeriod
+	if opts.Hourly {
+		if hourly, err = weather.FetchHourlyForecast(ctx, loc.Lat, loc.Lon); err != nil {
+			log.Printf("No hourly forecast: %v", err)
+		}
+	}
 
-	for i := 0; i < 24; i++ {
-		frameTime := baseTime.Add(time.Duration(-i*5) * time.Minute)
+	var winter weather.Winter
+	if weather.WinterLikely(alerts, obs) {
+		winter = weather.FetchWinter(ctx, loc.Lat, loc.Lon, obs.StationID)
+	}
 
-		minutes := frameTime.Minute()
-		minutes = (minutes / 5) * 5
-		frameTime = time.Date(frameTime.Year(), frameTime.Month(), frameTime.Day(),
-			frameTime.Hour(), minutes, 0, 0, time.UTC)
+	location := fmt.Sprintf("%s, %s", loc.City, loc.State)
+
+	return LoadedMsg{
+		Radar: Data{
+			Frames:      frames,
+			Lat:         lat,
+			Lon:         lon,
+			HomeLat:     loc.Lat,
+			HomeLon:     loc.Lon,
+			Location:    location,
+			Station:     station,
+			LastUpdated: time.Now(),
+			IsRealData:  isRealData,
+			Celsius:     obs.Celsius,
+			Conditions:  obs.Conditions,
+			Humidity:    obs.Humidity,
+			DewpointC:   obs.DewpointC,
+			PressurePa:  obs.PressurePa,
+			Alerts:      alerts,
+			Secondary:   secondary,
+			FetchErrors: FetchErrors{Conditions: conditionsErr, Alerts: alertsErr},
+			Wind:        wind,
+			Hourly:      hourly,
+			Winter:      winter,
+
+			ObservationStation: obs.StationID,
+			Geocoder:           loc.Source,
+			Scale:              scale,
+		},
+	}
+}
 
-		timeStr := frameTime.Format("200601021504")
-		radarURL := fmt.Sprintf("https://mesonet.agron.iastate.edu/cgi-bin/wms/nexrad/n0r.cgi?SERVICE=WMS&VERSION=1.1.1&REQUEST=GetMap&FORMAT=image/png&TRANSPARENT=true&LAYERS=nexrad-n0r&WIDTH=%d&HEIGHT=%d&SRS=EPSG:4326&BBOX=%f,%f,%f,%f&TIME=%s",
-			config.RadarWidth*4, config.RadarHeight*4,
-			lon-2.5, lat-2.0, lon+2.5, lat+2.0,
-			timeStr,
-		)
+// plannedFrames is how many frame downloads a load expects: a full loop, and
+// as many again for a secondary product and for a station overlay (private
+// helper)
+func plannedFrames(opts Options) int {
+	loops := 1
+	if opts.SecondaryProduct != "" {
+		loops++
+	}
+	if opts.StationOverlay && opts.ReplayTime.IsZero() && opts.ForceSource == "" {
+		loops++
+	}
+	return loops * config.MaxFrames
+}
 
-		resp, err := client.Get(radarURL)
-		if err != nil {
-			continue
+// fetchRealRadarData fetches the latest loop, from a custom source alone
+// when one is configured and otherwise from RainViewer then Iowa State
+func fetchRealRadarData(ctx context.Context, source SourceTemplate, custom bool, station string, lat, lon, scale float64, interval time.Duration, retries int) ([]Frame, bool, error) {
+	interval = loopInterval(interval)
+
+	// First try RainViewer, unless a coarser spacing than its own was asked
+	// for; only the Iowa State archive can honor that
+	if !custom && interval <= rainViewerInterval {
+		frames, err := fetchFromRainViewer(ctx, lat, lon, scale, retries)
+		if err == nil && len(frames) > 0 {
+			log.Printf("Successfully fetched %d frames from RainViewer", len(frames))
+			return frames, true, nil
 		}
-		defer resp.Body.Close()
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			continue
-		}
+	// Fallback to Iowa State University, or the configured source
+	frames, err := fetchSourceLoop(ctx, source, lat, lon, interval)
+	if err != nil {
+		return nil, false, err
+	}
+	return frames, true, nil
+}
 
-		img, err := png.Decode(resp.Body)
+// fetchForcedFrames fetches the latest loop from only the named source, so
+// problems with one source aren't hidden by falling back to another
+func fetchForcedFrames(ctx context.Context, force, station string, lat, lon, scale float64, interval time.Duration, retries int) ([]Frame, bool, error) {
+	interval = loopInterval(interval)
+
+	switch force {
+	case config.RadarSourceRainViewer:
+		frames, err := fetchFromRainViewer(ctx, lat, lon, scale, retries)
+		if err == nil && len(frames) == 0 {
+			err = ErrNoCoverage
+		}
 		if err != nil {
-			continue
+			return nil, false, fmt.Errorf("%s: %w", SourceRainViewer, err)
 		}
+		return frames, true, nil
+	case config.RadarSourceIowaState:
+		frames, err := fetchSourceLoop(ctx, iowaStateN0R.Scaled(scale), lat, lon, interval)
+		return frames, err == nil, err
+	case config.RadarSourceNWS:
+		frames, err := fetchSourceLoop(ctx, nwsMRMS.Scaled(scale), lat, lon, interval)
+		return frames, err == nil, err
+	case config.RadarSourceSimulated:
+		return generateRadarFrames(station, config.MaxFrames), false, nil
+	}
+	return nil, false, fmt.Errorf("unknown radar source %q", force)
+}
 
-		data := imageToRadarData(img)
-		if data != nil {
-			frame := Frame{
-				Data:      data,
-				Timestamp: frameTime,
-				Product:   "N0R",
+// sourceLoopTimes is how many intervals back fetchSourceLoop looks for
+// frames before settling for a short loop
+const sourceLoopTimes = 24
+
+// fetchSourceLoop walks back from the present one interval at a time,
+// skipping times the source can't supply, until it has a full loop. The
+// newest MaxFrames times are fetched together, then older ones only to fill
+// gaps. Frames are returned oldest first.
+func fetchSourceLoop(ctx context.Context, source SourceTemplate, lat, lon float64, interval time.Duration) ([]Frame, error) {
+	// The timeout applies to each request, not the whole loop
+	client := httpclient.New(httpclient.LoopTimeout)
+	frames := []Frame{}
+	baseTime := roundToInterval(time.Now(), interval)
+
+	for start := 0; start < sourceLoopTimes && len(frames) < config.MaxFrames && ctx.Err() == nil; {
+		end := min(sourceLoopTimes, start+config.MaxFrames-len(frames))
+		results := fetchFramePool(ctx, end-start, func(i int) (Frame, error) {
+			frameTime := baseTime.Add(-time.Duration(start+i) * interval)
+			frame, err := fetchSourceFrame(ctx, client, source, lat, lon, frameTime)
+			if err != nil {
+				log.Printf("Skipping frame at %s: %v", frameTime.Format(time.RFC3339), err)
+			}
+			return frame, err
+		})
+		for _, result := range results {
+			if result.err == nil {
+				frames = append(frames, result.frame)
 			}
-			frames = append(frames, frame)
-		}
-
-		if len(frames) >= config.MaxFrames {
-			break
 		}
+		start = end
 	}
 
 	if len(frames) == 0 {
-		return nil, false, fmt.Errorf("no radar data available")
+		return nil, fmt.Errorf("%w from %s", ErrNoCoverage, source.name)
 	}
 
 	// Reverse frames so oldest is first
@@ -160,19 +522,29 @@ func fetchRealRadarData(station string, lat, lon float64) ([]Frame, bool, error)
 		frames[i], frames[opp] = frames[opp], frames[i]
 	}
 
-	log.Printf("Successfully fetched %d frames from Iowa State", len(frames))
-	return frames, true, nil
+	log.Printf("Successfully fetched %d frames from %s", len(frames), source.name)
+	return frames, nil
 }
 
-func fetchFromRainViewer(lat, lon float64) ([]Frame, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
+// fetchFromRainViewer fetches RainViewer's past frames as the tile around
+// lat, lon, dropping a zoom level for each doubling of scale and adding one
+// for each halving. The frame
+// index gates the whole path, so it's retried before giving up; tiles are
+// downloaded concurrently, and any that fail are left out of the loop.
+func fetchFromRainViewer(ctx context.Context, lat, lon, scale float64, retries int) ([]Frame, error) {
+	// The timeout applies to each request, not the whole loop
+	client := httpclient.New(httpclient.ServiceTimeout)
 
-	resp, err := client.Get("https://api.rainviewer.com/public/weather-maps.json")
+	resp, err := httpclient.GetRetry(ctx, client, "https://api.rainviewer.com/public/weather-maps.json", retries, rainViewerRetryDelay)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("RainViewer index returned status %d", resp.StatusCode)
+	}
+
 	var apiData struct {
 		Radar struct {
 			Past []struct {
@@ -190,35 +562,27 @@ func fetchFromRainViewer(lat, lon float64) ([]Frame, error) {
 		return nil, err
 	}
 
-	frames := []Frame{}
-
-	for _, past := range apiData.Radar.Past {
-		zoom := 7
-		tileX, tileY := latLonToTile(lat, lon, zoom)
+	zoom := rainViewerZoom
+	if scale > 0 {
+		zoom -= int(math.Round(math.Log2(scale)))
+	}
+	tileX, tileY := latLonToTile(lat, lon, zoom)
 
+	past := apiData.Radar.Past
+	results := fetchFramePool(ctx, len(past), func(i int) (Frame, error) {
 		tileURL := fmt.Sprintf("https://tilecache.rainviewer.com%s/512/%d/%d/%d/6/1_1.png",
-			past.Path, zoom, tileX, tileY)
+			past[i].Path, zoom, tileX, tileY)
+		return cachedFrame(tileURL, func() (Frame, error) {
+			return fetchRainViewerTile(ctx, client, tileURL, time.Unix(past[i].Time, 0))
+		})
+	})
 
-		resp, err := client.Get(tileURL)
-		if err != nil {
-			continue
-		}
-		defer resp.Body.Close()
-
-		img, err := png.Decode(resp.Body)
-		if err != nil {
+	frames := []Frame{}
+	for _, result := range results {
+		if result.err != nil {
 			continue
 		}
-
-		data := imageToRadarData(img)
-		if data != nil {
-			frame := Frame{
-				Data:      data,
-				Timestamp: time.Unix(past.Time, 0),
-				Product:   "Composite",
-			}
-			frames = append(frames, frame)
-		}
+		frames = append(frames, result.frame)
 
 		if len(frames) >= config.MaxFrames {
 			break
@@ -228,6 +592,33 @@ func fetchFromRainViewer(lat, lon float64) ([]Frame, error) {
 	return frames, nil
 }
 
+// fetchRainViewerTile downloads and decodes one RainViewer frame (private
+// helper)
+func fetchRainViewerTile(ctx context.Context, client *http.Client, tileURL string, timestamp time.Time) (Frame, error) {
+	resp, err := httpclient.Fetch(ctx, client, tileURL)
+	if err != nil {
+		return Frame{}, err
+	}
+	defer resp.Body.Close()
+
+	img, err := png.Decode(resp.Body)
+	if err != nil {
+		return Frame{}, err
+	}
+
+	data, hasPrecip := imageToRadarData(img)
+	if data == nil {
+		return Frame{}, fmt.Errorf("empty RainViewer tile for %s", timestamp.UTC().Format(time.RFC3339))
+	}
+	return Frame{
+		Data:      data,
+		Timestamp: timestamp,
+		Product:   "Composite",
+		Source:    SourceRainViewer,
+		HasPrecip: hasPrecip,
+	}, nil
+}
+
 func latLonToTile(lat, lon float64, zoom int) (int, int) {
 	n := math.Pow(2, float64(zoom))
 	x := int((lon + 180.0) / 360.0 * n)
@@ -236,7 +627,9 @@ func latLonToTile(lat, lon float64, zoom int) (int, int) {
 	return x, y
 }
 
-func imageToRadarData(img image.Image) [][]int {
+// imageToRadarData decodes a reflectivity image onto the radar grid,
+// reporting whether any precipitation was found
+func imageToRadarData(img image.Image) ([][]int, bool) {
 	bounds := img.Bounds()
 	width := bounds.Dx()
 	height := bounds.Dy()
@@ -300,7 +693,7 @@ func imageToRadarData(img image.Image) [][]int {
 		log.Printf("No precipitation detected in radar image")
 	}
 
-	return data
+	return data, foundPrecipitation
 }
 
 func generateRadarFrames(station string, count int) []Frame {
@@ -340,6 +733,8 @@ func generateRadarFrames(station string, count int) []Frame {
 		frames[i] = Frame{
 			Data:      data,
 			Timestamp: time.Now().Add(time.Duration(i*10) * time.Minute),
+			Source:    SourceSimulated,
+			HasPrecip: HasEchoes(data),
 		}
 	}
 
@@ -0,0 +1,48 @@
+package radar
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// ErrNoCoverage is returned when a source answered but had no frames for the
+// area or time asked for
+var ErrNoCoverage = errors.New("no radar coverage")
+
+// Failure says what kind of problem stopped a load, so the error screen can
+// explain it in plain words instead of showing the error chain
+type Failure int
+
+const (
+	FailureOther             Failure = iota // anything else, shown as is
+	FailureInvalid                          // the query isn't a ZIP code or place name
+	FailureNotFound                         // the query parsed but no such location exists
+	FailureNetwork                          // a service couldn't be reached or didn't answer in time
+	FailureNoCoverage                       // the services answered without frames for the area or time
+	FailureUnsupportedPrefix                // a ZIP prefix with no built-in region
+)
+
+// ClassifyError sorts an error from LoadData into a Failure. The geocoder
+// chain reports a provider it couldn't reach over a later provider's miss, so
+// a location not found is the query's fault.
+func ClassifyError(err error) Failure {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return FailureOther
+	case errors.Is(err, weather.ErrInvalidPlace):
+		return FailureInvalid
+	case errors.Is(err, weather.ErrUnsupportedPrefix):
+		return FailureUnsupportedPrefix
+	case errors.Is(err, weather.ErrLocationNotFound):
+		return FailureNotFound
+	case errors.Is(err, ErrNoCoverage):
+		return FailureNoCoverage
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr):
+		return FailureNetwork
+	}
+	return FailureOther
+}
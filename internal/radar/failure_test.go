@@ -0,0 +1,44 @@
+package radar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+func TestClassifyError(t *testing.T) {
+	unreachable := &url.Error{Op: "Get", URL: "https://api.weather.gov/alerts", Err: &net.OpError{
+		Op: "dial", Net: "tcp", Err: errors.New("connect: network is unreachable"),
+	}}
+	noDNS := &url.Error{Op: "Get", URL: "https://api.zippopotam.us/us/80202", Err: &net.DNSError{
+		Err: "no such host", Name: "api.zippopotam.us",
+	}}
+
+	tests := []struct {
+		name string
+		err  error
+		want Failure
+	}{
+		{"nil", nil, FailureOther},
+		{"unknown ZIP", fmt.Errorf("failed to geocode ZIP: %w", weather.ErrLocationNotFound), FailureNotFound},
+		{"unparseable place", weather.ErrInvalidPlace, FailureInvalid},
+		{"unsupported ZIP prefix", fmt.Errorf("%w: 000 has none of the built-in cities", weather.ErrUnsupportedPrefix), FailureUnsupportedPrefix},
+		{"empty replay", fmt.Errorf("%w: no archived frames for KDIX", ErrNoCoverage), FailureNoCoverage},
+		{"network unreachable", fmt.Errorf("failed to geocode ZIP: unable to find location for 80202: %w", unreachable), FailureNetwork},
+		{"DNS lookup failed", noDNS, FailureNetwork},
+		{"load timed out", fmt.Errorf("%w after 30s loading radar for 80202", ErrTimeout), FailureNetwork},
+		{"request deadline", fmt.Errorf("rainviewer: %w", context.DeadlineExceeded), FailureNetwork},
+		{"bad custom source", errors.New("radar source must include {bbox}"), FailureOther},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyError(tt.err); got != tt.want {
+			t.Errorf("%s: ClassifyError = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
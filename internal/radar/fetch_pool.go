@@ -0,0 +1,50 @@
+package radar
+
+import (
+	"context"
+	"sync"
+)
+
+// frameFetchWorkers bounds how many frame downloads run at once
+const frameFetchWorkers = 6
+
+// frameResult is the outcome of one frame download
+type frameResult struct {
+	frame Frame
+	err   error
+}
+
+// fetchFramePool calls fetch for each index below n on a bounded pool of
+// workers and returns the results by index, so callers keep frame order no
+// matter which download finishes first. Indexes not started before ctx is
+// done report its error. Each finished download counts towards the load's
+// progress (see planFrames).
+func fetchFramePool(ctx context.Context, n int, fetch func(i int) (Frame, error)) []frameResult {
+	results := make([]frameResult, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < min(frameFetchWorkers, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := ctx.Err(); err != nil {
+					results[i] = frameResult{err: err}
+					continue
+				}
+				frame, err := fetch(i)
+				results[i] = frameResult{frame: frame, err: err}
+				frameFinished(ctx)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
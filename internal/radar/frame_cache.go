@@ -0,0 +1,21 @@
+package radar
+
+import "github.com/N-Erickson/termidar/internal/cache"
+
+// cachedFrame returns the decoded frame cached for an image URL, or fetches
+// and caches it. The URL encodes both where and when the frame is, so it
+// identifies a frame across runs; published frames don't change, and the
+// cache's TTL keeps a relaunch from reusing a loop that has moved on.
+func cachedFrame(url string, fetch func() (Frame, error)) (Frame, error) {
+	var frame Frame
+	if cache.Get(cache.KindFrame, url, &frame) {
+		return frame, nil
+	}
+
+	frame, err := fetch()
+	if err != nil {
+		return Frame{}, err
+	}
+	cache.Put(cache.KindFrame, url, frame)
+	return frame, nil
+}
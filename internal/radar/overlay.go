@@ -0,0 +1,87 @@
+package radar
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// stationRangeMiles is how far from the radar a single-station scan is
+// trusted over the composite, about the 230 km reach of NEXRAD reflectivity
+const stationRangeMiles = 143.0
+
+// overlayStation merges the nearest station's reflectivity over the loop at
+// each frame's time. Within the station's range its sharper scan replaces
+// the composite, dry cells included; beyond it, or where a time couldn't be
+// fetched, the composite is kept.
+func overlayStation(ctx context.Context, frames []Frame, station string, lat, lon, scale float64) []Frame {
+	stationLat, stationLon, ok := weather.RadarStationLocation(station)
+	if !ok || len(frames) == 0 {
+		return frames
+	}
+
+	times := make([]time.Time, len(frames))
+	for i, frame := range frames {
+		times[i] = frame.Timestamp
+	}
+	local := fetchProductFrames(ctx, station, ProductReflectivity, lat, lon, scale, times)
+
+	inRange := stationCoverage(stationLat, stationLon, lat, lon, scale)
+	merged := 0
+	for i := range frames {
+		if local[i].Data == nil || frames[i].Data == nil {
+			continue
+		}
+		frames[i] = mergeFrames(frames[i], local[i], inRange)
+		frames[i].Overlay = station
+		merged++
+	}
+
+	log.Printf("Merged %s reflectivity into %d of %d frames", station, merged, len(frames))
+	return frames
+}
+
+// stationCoverage marks the grid cells within stationRangeMiles of the
+// station, for a view of the given scale centered on lat, lon (private
+// helper)
+func stationCoverage(stationLat, stationLon, lat, lon, scale float64) [][]bool {
+	halfWidth, halfHeight := sourceHalfExtent(scale)
+	degreesPerCellX := 2 * halfWidth / config.RadarWidth
+	degreesPerCellY := 2 * halfHeight / config.RadarHeight
+	milesPerDegreeLon := 69.0 * math.Cos(stationLat*math.Pi/180)
+
+	covered := make([][]bool, config.RadarHeight)
+	for y := range covered {
+		covered[y] = make([]bool, config.RadarWidth)
+		cellLat := lat + halfHeight - (float64(y)+0.5)*degreesPerCellY
+		for x := range covered[y] {
+			cellLon := lon - halfWidth + (float64(x)+0.5)*degreesPerCellX
+			miles := math.Hypot((cellLat-stationLat)*69.0, (cellLon-stationLon)*milesPerDegreeLon)
+			covered[y][x] = miles <= stationRangeMiles
+		}
+	}
+	return covered
+}
+
+// mergeFrames returns the composite with the local scan's cells in place of
+// its own wherever inRange is set (private helper)
+func mergeFrames(composite, local Frame, inRange [][]bool) Frame {
+	data := make([][]int, len(composite.Data))
+	for y, row := range composite.Data {
+		data[y] = append([]int(nil), row...)
+		for x := range data[y] {
+			if y < len(inRange) && x < len(inRange[y]) && inRange[y][x] &&
+				y < len(local.Data) && x < len(local.Data[y]) {
+				data[y][x] = local.Data[y][x]
+			}
+		}
+	}
+
+	composite.Data = data
+	composite.HasPrecip = HasEchoes(data)
+	return composite
+}
@@ -0,0 +1,61 @@
+package radar
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+func TestStationCoverage(t *testing.T) {
+	// KFTG, east of Denver, in a standard view centered on the station
+	const lat, lon = 39.7867, -104.5458
+
+	// The standard view reaches about 135 miles east and west of the
+	// station and 190 to its corners
+	covered := stationCoverage(lat, lon, lat, lon, 1)
+	middle := config.RadarHeight / 2
+	for x := range covered[middle] {
+		if !covered[middle][x] {
+			t.Errorf("cell (%d, %d) on the station's row is out of range", x, middle)
+		}
+	}
+	if covered[0][0] || covered[config.RadarHeight-1][config.RadarWidth-1] {
+		t.Errorf("corners of the standard view are in range")
+	}
+
+	// Zoomed out, the station's row runs off well past its range
+	covered = stationCoverage(lat, lon, lat, lon, 3)
+	if !covered[middle][config.RadarWidth/2] {
+		t.Errorf("center cell of a zoomed-out view is out of range")
+	}
+	if covered[middle][0] || covered[middle][config.RadarWidth-1] {
+		t.Errorf("ends of the station's row in a zoomed-out view are in range")
+	}
+}
+
+func TestMergeFrames(t *testing.T) {
+	composite := grid([]int{3, 3, 3}, []int{3, 3, 3})
+	local := grid([]int{8, 0, 8}, []int{8, 0})
+	inRange := [][]bool{{true, true, false}, {true, true, true}}
+
+	merged := mergeFrames(composite, local, inRange)
+
+	// Dry local cells in range win too; cells out of range or beyond the
+	// local scan keep the composite
+	want := [][]int{{8, 0, 3}, {8, 0, 3}}
+	if !reflect.DeepEqual(merged.Data, want) {
+		t.Errorf("merged = %v, want %v", merged.Data, want)
+	}
+	if !merged.HasPrecip {
+		t.Errorf("merged frame with echoes has HasPrecip unset")
+	}
+	if composite.Data[0][0] != 3 {
+		t.Errorf("merging changed the composite frame's data")
+	}
+
+	dry := mergeFrames(grid([]int{5}), grid([]int{0}), [][]bool{{true}})
+	if dry.HasPrecip {
+		t.Errorf("frame left dry by the local scan has HasPrecip set")
+	}
+}
@@ -0,0 +1,196 @@
+package radar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Stage is the step a load is working on
+type Stage int
+
+// Load stages, in the order a load moves through them
+const (
+	StageLocating Stage = iota
+	StageStation
+	StageConditions
+	StageFrames
+	StageProcessing
+)
+
+// Share of the progress bar reached at the start of each stage; frames
+// fill the span up to StageProcessing one by one
+var stageFractions = map[Stage]float64{
+	StageLocating:   0,
+	StageStation:    0.1,
+	StageConditions: 0.15,
+	StageFrames:     0.3,
+	StageProcessing: 0.9,
+}
+
+// ProgressMsg reports how far a load has got. The load carries on either
+// way; run Next to wait for its next message, either more progress or the
+// LoadedMsg or ErrorMsg that ends it.
+type ProgressMsg struct {
+	Stage Stage
+	// Fraction is how much of the load is done, from 0 to 1
+	Fraction float64
+	// Frames and TotalFrames count the radar frames fetched so far during
+	// StageFrames
+	Frames      int
+	TotalFrames int
+
+	load *pendingLoad
+}
+
+// Next returns the command that waits for the load's next message
+func (p ProgressMsg) Next() tea.Cmd {
+	return p.load.wait
+}
+
+// Cancel abandons the load, stopping its requests; its final message is
+// then an ErrorMsg wrapping context.Canceled
+func (p ProgressMsg) Cancel() {
+	if p.load != nil {
+		p.load.cancel()
+	}
+}
+
+// Await runs a LoadData command to completion, skipping its progress, for
+// callers outside a Bubble Tea program
+func Await(cmd tea.Cmd) tea.Msg {
+	msg := cmd()
+	for {
+		progress, ok := msg.(ProgressMsg)
+		if !ok {
+			return msg
+		}
+		msg = progress.Next()()
+	}
+}
+
+// pendingLoad connects a running load to the commands waiting on it
+type pendingLoad struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	query   string
+	timeout time.Duration
+	// progress holds the latest update nobody has read yet; older unread
+	// updates are dropped, so a slow reader never holds up the load
+	progress chan ProgressMsg
+	result   chan tea.Msg
+	// frames counts every frame download of the load, across batches,
+	// fallbacks and the secondary product
+	frames frameCounter
+}
+
+// progressKey finds the pendingLoad in a load's context
+type progressKey struct{}
+
+// wait returns the load's next message. Requests already in flight can't
+// all be interrupted, so a load past its deadline is reported as timed out
+// without waiting for it to notice.
+func (l *pendingLoad) wait() tea.Msg {
+	select {
+	case msg := <-l.result:
+		return msg
+	case p := <-l.progress:
+		p.load = l
+		return p
+	case <-l.ctx.Done():
+		// The context is also canceled once the load has finished
+		select {
+		case msg := <-l.result:
+			return msg
+		default:
+		}
+		if !errors.Is(l.ctx.Err(), context.DeadlineExceeded) {
+			return ErrorMsg{Err: fmt.Errorf("loading radar for %s: %w", l.query, l.ctx.Err())}
+		}
+		return ErrorMsg{Err: fmt.Errorf("%w after %s loading radar for %s", ErrTimeout, l.timeout, l.query)}
+	}
+}
+
+// report replaces any unread update with p
+func (l *pendingLoad) report(p ProgressMsg) {
+	select {
+	case <-l.progress:
+	default:
+	}
+	select {
+	case l.progress <- p:
+	default:
+	}
+}
+
+// reportStage records that a load has reached stage, if the load is being
+// tracked (private helper)
+func reportStage(ctx context.Context, stage Stage) {
+	if l, ok := ctx.Value(progressKey{}).(*pendingLoad); ok {
+		l.report(ProgressMsg{Stage: stage, Fraction: stageFractions[stage]})
+	}
+}
+
+// frameCounter counts a load's frame downloads against the number planned
+// for the whole load, so the bar only ever moves forward (private helper)
+type frameCounter struct {
+	total int
+	done  atomic.Int32
+}
+
+// planFrames sets how many frame downloads the load expects in all; call it
+// before any are fetched (private helper)
+func planFrames(ctx context.Context, total int) {
+	if l, ok := ctx.Value(progressKey{}).(*pendingLoad); ok {
+		l.frames.total = total
+	}
+}
+
+// frameFinished counts one more frame download, whether or not it succeeded
+// (private helper)
+func frameFinished(ctx context.Context) {
+	if l, ok := ctx.Value(progressKey{}).(*pendingLoad); ok {
+		l.reportFrames(int(l.frames.done.Add(1)))
+	}
+}
+
+// framesReached moves the count up to at least done, for when a step of the
+// load finishes having used fewer downloads than planned (private helper)
+func framesReached(ctx context.Context, done int) {
+	l, ok := ctx.Value(progressKey{}).(*pendingLoad)
+	if !ok {
+		return
+	}
+	for {
+		current := l.frames.done.Load()
+		if int(current) >= done {
+			return
+		}
+		if l.frames.done.CompareAndSwap(current, int32(done)) {
+			l.reportFrames(done)
+			return
+		}
+	}
+}
+
+// reportFrames records that done of the planned frame downloads have
+// finished. Fallbacks can use more than planned, so the count is capped
+// rather than running past the end of the stage.
+func (l *pendingLoad) reportFrames(done int) {
+	total := l.frames.total
+	if total <= 0 {
+		return
+	}
+	done = min(done, total)
+	start, end := stageFractions[StageFrames], stageFractions[StageProcessing]
+	l.report(ProgressMsg{
+		Stage:       StageFrames,
+		Fraction:    start + (end-start)*float64(done)/float64(total),
+		Frames:      done,
+		TotalFrames: total,
+	})
+}
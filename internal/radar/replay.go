@@ -0,0 +1,105 @@
+package radar
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// archiveInterval is the spacing of the Iowa State composite archive; loop
+// intervals are multiples of it
+const archiveInterval = 5 * time.Minute
+
+// roundToInterval truncates t to a loop interval boundary
+func roundToInterval(t time.Time, interval time.Duration) time.Time {
+	return t.UTC().Truncate(interval)
+}
+
+// loopInterval returns the requested frame spacing, defaulting to the
+// archive's own and rounding up to a multiple of it
+func loopInterval(interval time.Duration) time.Duration {
+	if interval <= archiveInterval {
+		return archiveInterval
+	}
+	return (interval + archiveInterval - 1) / archiveInterval * archiveInterval
+}
+
+// ReplayTimeLayout is the local date format accepted for replay times
+const ReplayTimeLayout = "2006-01-02 15:04"
+
+// archiveStart is roughly when the Iowa State n0r composite archive begins
+var archiveStart = time.Date(1995, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ParseReplayTime parses a replay time in ReplayTimeLayout (local time) or
+// RFC 3339, rejecting times the archive can't have
+func ParseReplayTime(s string) (time.Time, error) {
+	return ParseReplayTimeIn(s, time.Local)
+}
+
+// ParseReplayTimeIn is ParseReplayTime with ReplayTimeLayout read in loc
+func ParseReplayTimeIn(s string, loc *time.Location) (time.Time, error) {
+	t, err := time.ParseInLocation(ReplayTimeLayout, s, loc)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, s)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q - use YYYY-MM-DD HH:MM", s)
+	}
+
+	if t.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("replay time %s is in the future", t.Local().Format(ReplayTimeLayout))
+	}
+	if t.Before(archiveStart) {
+		return time.Time{}, fmt.Errorf("the radar archive starts in %d", archiveStart.Year())
+	}
+	return t, nil
+}
+
+// fetchReplayFrames builds a loop of archived frames centered on the given
+// time
+func fetchReplayFrames(ctx context.Context, source SourceTemplate, station string, lat, lon float64, center time.Time, interval time.Duration) ([]Frame, error) {
+	interval = loopInterval(interval)
+	end := roundToInterval(center, interval).Add(time.Duration(config.MaxFrames/2-1) * interval)
+	return fetchHistorical(ctx, source, station, lat, lon, end, config.MaxFrames, interval)
+}
+
+// fetchHistorical builds a loop of up to count archived frames spaced
+// interval apart and ending at t, oldest first. Frames the archive is missing
+// are skipped rather than faked, and frames after the present are never
+// requested.
+func fetchHistorical(ctx context.Context, source SourceTemplate, station string, lat, lon float64, t time.Time, count int, interval time.Duration) ([]Frame, error) {
+	client := httpclient.New(httpclient.LoopTimeout)
+	interval = loopInterval(interval)
+
+	end := roundToInterval(t, interval)
+	if now := roundToInterval(time.Now(), interval); end.After(now) {
+		end = now
+	}
+	start := end.Add(-time.Duration(count-1) * interval)
+
+	var frames []Frame
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		frameTime := start.Add(time.Duration(i) * interval)
+
+		frame, err := fetchSourceFrame(ctx, client, source, lat, lon, frameTime)
+		frameFinished(ctx)
+		if err != nil {
+			log.Printf("No archived frame for %s at %s: %v", station, frameTime.Format(time.RFC3339), err)
+			continue
+		}
+
+		frames = append(frames, frame)
+	}
+
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("%w: no archived frames for %s between %s and %s",
+			ErrNoCoverage, station, start.Local().Format(ReplayTimeLayout), end.Local().Format("15:04"))
+	}
+
+	log.Printf("Fetched %d of %d archived frames for %s", len(frames), count, station)
+	return frames, nil
+}
@@ -0,0 +1,184 @@
+package radar
+
+import (
+	"context"
+	"fmt"
+	"image/png"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// Half-extents of the area requested around the view center, in degrees
+const (
+	sourceHalfWidth  = 2.5
+	sourceHalfHeight = 2.0
+)
+
+// sourceHalfExtent returns the half-extents requested for a view covering
+// scale times the standard area, the standard area for zero or less
+// (private helper)
+func sourceHalfExtent(scale float64) (float64, float64) {
+	if scale <= 0 {
+		scale = 1
+	}
+	return sourceHalfWidth * scale, sourceHalfHeight * scale
+}
+
+// placeholderPattern matches {name} and {name:format} in a source template
+var placeholderPattern = regexp.MustCompile(`\{([a-z]+)(?::([^{}]*))?\}`)
+
+// SourceTemplate is a WMS-style GetMap URL whose placeholders are filled in
+// for each frame:
+//
+//	{bbox}    minLon,minLat,maxLon,maxLat around the view center (EPSG:4326)
+//	{width}   image width in pixels
+//	{height}  image height in pixels
+//	{time}    frame time in UTC as RFC 3339, or {time:LAYOUT} with a Go layout
+type SourceTemplate struct {
+	raw  string
+	host string
+	name string
+	// scale widens the requested area for a zoomed-out view; zero is the
+	// standard size
+	scale float64
+}
+
+// iowaStateN0R is the Iowa State n0r composite, which serves both recent
+// and archived scans and backs the loop when no custom source is set
+var iowaStateN0R = mustParseSourceTemplate(SourceIowaState, "https://mesonet.agron.iastate.edu/cgi-bin/wms/nexrad/n0r.cgi?SERVICE=WMS&VERSION=1.1.1&REQUEST=GetMap&FORMAT=image/png&TRANSPARENT=true&LAYERS=nexrad-n0r&WIDTH={width}&HEIGHT={height}&SRS=EPSG:4326&BBOX={bbox}&TIME={time:200601021504}")
+
+// nwsMRMS is the NWS MRMS quality-controlled base reflectivity mosaic
+var nwsMRMS = mustParseSourceTemplate(SourceNWS, "https://opengeo.ncep.noaa.gov/geoserver/conus/conus_bref_qcd/ows?SERVICE=WMS&VERSION=1.1.1&REQUEST=GetMap&FORMAT=image/png&TRANSPARENT=true&LAYERS=conus_bref_qcd&WIDTH={width}&HEIGHT={height}&SRS=EPSG:4326&BBOX={bbox}&TIME={time}")
+
+// ParseSourceTemplate validates a custom radar source URL template. It must
+// be an http(s) URL using only the known placeholders, and include {bbox}
+// so frames cover the location being viewed.
+func ParseSourceTemplate(raw string) (SourceTemplate, error) {
+	raw = strings.TrimSpace(raw)
+	hasBBox := false
+	for _, match := range placeholderPattern.FindAllStringSubmatch(raw, -1) {
+		switch match[1] {
+		case "bbox":
+			hasBBox = true
+		case "width", "height":
+		case "time":
+			continue
+		default:
+			return SourceTemplate{}, fmt.Errorf("unknown placeholder {%s} in radar source", match[1])
+		}
+		if match[2] != "" {
+			return SourceTemplate{}, fmt.Errorf("placeholder {%s} doesn't take a format", match[1])
+		}
+	}
+	if !hasBBox {
+		return SourceTemplate{}, fmt.Errorf("radar source must include {bbox}")
+	}
+
+	// Parse with the placeholders stripped so braces don't trip the parser
+	stripped := placeholderPattern.ReplaceAllString(raw, "0")
+	if strings.ContainsAny(stripped, "{}") {
+		return SourceTemplate{}, fmt.Errorf("unbalanced braces in radar source")
+	}
+	u, err := url.Parse(stripped)
+	if err != nil {
+		return SourceTemplate{}, fmt.Errorf("invalid radar source: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return SourceTemplate{}, fmt.Errorf("radar source must be an http or https URL")
+	}
+
+	return SourceTemplate{raw: raw, host: u.Host, name: u.Host}, nil
+}
+
+// mustParseSourceTemplate is ParseSourceTemplate for built-in templates,
+// which are named rather than labeled by host
+func mustParseSourceTemplate(name, raw string) SourceTemplate {
+	source, err := ParseSourceTemplate(raw)
+	if err != nil {
+		panic(err)
+	}
+	source.name = name
+	return source
+}
+
+// Name returns the host of a custom source, or a built-in source's name
+func (s SourceTemplate) Name() string { return s.name }
+
+// Scaled returns the source requesting scale times the standard area, less
+// for a scale under 1; zero or less requests the standard area
+func (s SourceTemplate) Scaled(scale float64) SourceTemplate {
+	s.scale = scale
+	return s
+}
+
+// URL fills in the template for a frame centered on lat, lon at frameTime
+func (s SourceTemplate) URL(lat, lon float64, frameTime time.Time) string {
+	halfWidth, halfHeight := sourceHalfExtent(s.scale)
+	return placeholderPattern.ReplaceAllStringFunc(s.raw, func(placeholder string) string {
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		switch match[1] {
+		case "bbox":
+			return fmt.Sprintf("%f,%f,%f,%f",
+				lon-halfWidth, lat-halfHeight, lon+halfWidth, lat+halfHeight)
+		case "width":
+			return strconv.Itoa(config.RadarWidth * 4)
+		case "height":
+			return strconv.Itoa(config.RadarHeight * 4)
+		default:
+			layout := match[2]
+			if layout == "" {
+				layout = time.RFC3339
+			}
+			return url.QueryEscape(frameTime.UTC().Format(layout))
+		}
+	})
+}
+
+// fetchSourceFrame fetches and decodes one reflectivity frame from a source,
+// reusing the cached copy if it was downloaded recently
+func fetchSourceFrame(ctx context.Context, client *http.Client, source SourceTemplate, lat, lon float64, frameTime time.Time) (Frame, error) {
+	imageURL := source.URL(lat, lon, frameTime)
+	return cachedFrame(imageURL, func() (Frame, error) {
+		return fetchSourceImage(ctx, client, source, imageURL, frameTime)
+	})
+}
+
+// fetchSourceImage downloads and decodes one frame from a source (private
+// helper)
+func fetchSourceImage(ctx context.Context, client *http.Client, source SourceTemplate, imageURL string, frameTime time.Time) (Frame, error) {
+	timeStr := frameTime.UTC().Format(time.RFC3339)
+
+	resp, err := httpclient.Fetch(ctx, client, imageURL)
+	if err != nil {
+		return Frame{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Frame{}, fmt.Errorf("%s returned status %d for %s", source.host, resp.StatusCode, timeStr)
+	}
+
+	img, err := png.Decode(resp.Body)
+	if err != nil {
+		return Frame{}, fmt.Errorf("decoding %s frame for %s: %w", source.host, timeStr, err)
+	}
+
+	data, hasPrecip := imageToRadarData(img)
+	if data == nil {
+		return Frame{}, fmt.Errorf("empty radar image for %s", timeStr)
+	}
+	return Frame{
+		Data:      data,
+		Timestamp: frameTime,
+		Product:   ProductReflectivity,
+		Source:    source.name,
+		HasPrecip: hasPrecip,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package radar
+
+import (
+	"math"
+	"sort"
+)
+
+// stormMinIntensity is the lowest intensity counted as part of a storm cell,
+// which leaves light rain out of cell detection
+const stormMinIntensity = 3
+
+// stormMaxStep is how far, in grid cells, a storm may move between frames and
+// still be treated as the same cell
+const stormMaxStep = 8.0
+
+// StormCell is a connected region of moderate or heavier precipitation
+type StormCell struct {
+	// X and Y are the intensity-weighted centroid in grid coordinates
+	X, Y float64
+	// Cells is the number of grid cells in the region
+	Cells int
+	// Peak is the highest intensity in the region
+	Peak int
+}
+
+// TrackPoint is a storm cell's position in one frame of a loop
+type TrackPoint struct {
+	Frame int
+	Cell  StormCell
+}
+
+// FindStormCells groups adjacent cells at or above stormMinIntensity into
+// storm cells, largest first
+func FindStormCells(data [][]int) []StormCell {
+	if len(data) == 0 {
+		return nil
+	}
+
+	seen := make([][]bool, len(data))
+	for y := range data {
+		seen[y] = make([]bool, len(data[y]))
+	}
+
+	var cells []StormCell
+	var stack [][2]int
+	for y := range data {
+		for x := range data[y] {
+			if seen[y][x] || data[y][x] < stormMinIntensity {
+				continue
+			}
+
+			// Flood fill the region, accumulating an intensity-weighted centroid
+			var cell StormCell
+			var weight, sumX, sumY float64
+			seen[y][x] = true
+			stack = append(stack[:0], [2]int{x, y})
+			for len(stack) > 0 {
+				p := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				cx, cy := p[0], p[1]
+				v := data[cy][cx]
+
+				cell.Cells++
+				if v > cell.Peak {
+					cell.Peak = v
+				}
+				weight += float64(v)
+				sumX += float64(cx) * float64(v)
+				sumY += float64(cy) * float64(v)
+
+				for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+					nx, ny := cx+d[0], cy+d[1]
+					if ny < 0 || ny >= len(data) || nx < 0 || nx >= len(data[ny]) {
+						continue
+					}
+					if !seen[ny][nx] && data[ny][nx] >= stormMinIntensity {
+						seen[ny][nx] = true
+						stack = append(stack, [2]int{nx, ny})
+					}
+				}
+			}
+
+			cell.X = sumX / weight
+			cell.Y = sumY / weight
+			cells = append(cells, cell)
+		}
+	}
+
+	sort.SliceStable(cells, func(i, j int) bool {
+		return cells[i].Cells > cells[j].Cells
+	})
+	return cells
+}
+
+// NearestCell returns the cell whose centroid is closest to x, y, if any is
+// within maxDist grid cells
+func NearestCell(cells []StormCell, x, y, maxDist float64) (StormCell, bool) {
+	var nearest StormCell
+	best := maxDist
+	found := false
+	for _, cell := range cells {
+		if d := math.Hypot(cell.X-x, cell.Y-y); d <= best {
+			best = d
+			nearest = cell
+			found = true
+		}
+	}
+	return nearest, found
+}
+
+// TrackStorm follows the cell nearest x, y in the newest frame back through
+// the loop, stopping where it can no longer be matched. The track is returned
+// oldest first.
+func TrackStorm(frames []Frame, x, y float64) []TrackPoint {
+	var track []TrackPoint
+	maxDist := stormMaxStep
+
+	for i := len(frames) - 1; i >= 0; i-- {
+		cell, ok := NearestCell(FindStormCells(frames[i].Data), x, y, maxDist)
+		if !ok {
+			if len(track) == 0 {
+				// The newest frames may have lost the cell; keep looking
+				// further back from the same spot
+				continue
+			}
+			break
+		}
+		track = append(track, TrackPoint{Frame: i, Cell: cell})
+		x, y = cell.X, cell.Y
+	}
+
+	// Reverse so the oldest point comes first
+	for i, j := 0, len(track)-1; i < j; i, j = i+1, j-1 {
+		track[i], track[j] = track[j], track[i]
+	}
+	return track
+}
+
+// StormMotion returns the average movement of a tracked storm in grid cells
+// per frame. A track of fewer than two points has no motion.
+func StormMotion(track []TrackPoint) (dx, dy float64) {
+	if len(track) < 2 {
+		return 0, 0
+	}
+
+	first, last := track[0], track[len(track)-1]
+	frames := float64(last.Frame - first.Frame)
+	if frames <= 0 {
+		return 0, 0
+	}
+	return (last.Cell.X - first.Cell.X) / frames, (last.Cell.Y - first.Cell.Y) / frames
+}
@@ -0,0 +1,94 @@
+package radar
+
+// trendRadius is how many cells around the center feed the trend series
+const trendRadius = 2
+
+// trendThreshold is the change in mean intensity treated as a real trend
+// rather than noise
+const trendThreshold = 0.75
+
+// CenterSeries returns the mean intensity within trendRadius cells of x, y for
+// each frame, oldest first
+func CenterSeries(frames []Frame, x, y int) []float64 {
+	series := make([]float64, len(frames))
+	for i, frame := range frames {
+		var sum float64
+		var count int
+		for dy := -trendRadius; dy <= trendRadius; dy++ {
+			for dx := -trendRadius; dx <= trendRadius; dx++ {
+				cy, cx := y+dy, x+dx
+				if cy < 0 || cy >= len(frame.Data) || cx < 0 || cx >= len(frame.Data[cy]) {
+					continue
+				}
+				sum += float64(frame.Data[cy][cx])
+				count++
+			}
+		}
+		if count > 0 {
+			series[i] = sum / float64(count)
+		}
+	}
+	return series
+}
+
+// DescribeTrend summarizes an intensity series in a few words, such as "rain
+// intensifying" or "storm has passed". It returns "" when the series never
+// shows precipitation.
+func DescribeTrend(series []float64) string {
+	if len(series) < 3 {
+		return ""
+	}
+
+	peak, peakAt := 0.0, 0
+	for i, v := range series {
+		if v > peak {
+			peak, peakAt = v, i
+		}
+	}
+	if peak == 0 {
+		return ""
+	}
+
+	last := series[len(series)-1]
+	third := max(1, len(series)/3)
+	early := mean(series[:third])
+	late := mean(series[len(series)-third:])
+	kind := precipKind(max(peak, last))
+
+	switch {
+	case last == 0 && peakAt < len(series)-1:
+		// It rained earlier in the loop and has stopped
+		return kind + " has passed"
+	case peakAt < len(series)-third && last < peak/2 && peak-last > trendThreshold:
+		return kind + " weakening after its peak"
+	case early == 0 && late > 0:
+		return kind + " arriving"
+	case late-early > trendThreshold:
+		return kind + " intensifying"
+	case early-late > trendThreshold:
+		return kind + " weakening"
+	}
+	return kind + " steady"
+}
+
+// precipKind names precipitation by mean intensity (private helper)
+func precipKind(intensity float64) string {
+	switch {
+	case intensity >= 6:
+		return "storm"
+	case intensity >= 3:
+		return "rain"
+	}
+	return "light rain"
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// polygonFilterNames labels the polygon filters for status messages
+var polygonFilterNames = map[string]string{
+	config.PolygonFilterAll:      "all alerts",
+	config.PolygonFilterWarnings: "warnings only",
+	config.PolygonFilterSevere:   "severe and extreme only",
+	config.PolygonFilterTornado:  "tornado warnings only",
+}
+
+// polygonShown reports whether an alert's polygon passes the filter. An
+// unknown filter shows everything.
+func polygonShown(filter string, alert weather.Alert) bool {
+	switch filter {
+	case config.PolygonFilterWarnings:
+		return strings.HasSuffix(alert.Event, "Warning")
+	case config.PolygonFilterSevere:
+		return alert.Severity == "Severe" || alert.Severity == "Extreme"
+	case config.PolygonFilterTornado:
+		return alert.Event == "Tornado Warning"
+	}
+	return true
+}
+
+// cycleAlertArea steps to the next (or previous) alert with a polygon,
+// centering the view on it. Stepping past either end returns to the searched
+// location.
+func (m Model) cycleAlertArea(step int) (Model, tea.Cmd) {
+	var areas []int
+	for i, alert := range m.radar.Alerts {
+		if len(alert.Polygon) >= 2 {
+			areas = append(areas, i)
+		}
+	}
+	if len(areas) == 0 {
+		m.statusMsg = "No active alerts with a mapped area"
+		return m, nil
+	}
+
+	// Position -1 is the home view, so the cycle runs home, 1, 2, ..., home
+	pos := -1
+	for i, idx := range areas {
+		if idx == m.alertIndex {
+			pos = i
+		}
+	}
+	pos += step
+	if pos >= len(areas) || pos < -1 {
+		pos = -1
+	}
+	if step < 0 && m.alertIndex < 0 {
+		pos = len(areas) - 1
+	}
+
+	// Showing an alert area takes over the view from follow mode
+	m.following = false
+	m.stormTrack = nil
+
+	if pos < 0 {
+		m.alertIndex = -1
+		m.viewCenter = nil
+		m.statusMsg = "Back to your location"
+		return m.reloadInBackground()
+	}
+
+	m.alertIndex = areas[pos]
+	alert := m.radar.Alerts[m.alertIndex]
+	lat, lon, _ := alert.Centroid()
+	m.viewCenter = &weather.Location{Lat: lat, Lon: lon}
+	m.statusMsg = fmt.Sprintf("Alert area %d/%d: %s", pos+1, len(areas), alert.Event)
+	return m.reloadInBackground()
+}
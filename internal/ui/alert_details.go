@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// alertDetailsWidth bounds how wide alert text is wrapped
+const alertDetailsWidth = 76
+
+// activeAlerts returns the loaded alerts still in effect, dropping any that
+// have ended since the last refresh
+func (m Model) activeAlerts() []weather.Alert {
+	now := time.Now()
+	var active []weather.Alert
+	for _, alert := range m.radar.Alerts {
+		if alert.Active(now) {
+			active = append(active, alert)
+		}
+	}
+	return active
+}
+
+// toggleAlertDetails opens the alert details screen at the top, or closes
+// it; with no active alerts there's nothing to open
+func (m Model) toggleAlertDetails() Model {
+	if !m.showAlerts && len(m.activeAlerts()) == 0 {
+		m.statusMsg = "No active alerts"
+		return m
+	}
+	m.showAlerts = !m.showAlerts
+	m.alertScroll = 0
+	return m
+}
+
+// updateAlertDetails handles a key press on the alert details screen: the
+// arrows and page keys scroll, ESC or ! returns to the radar, and quitting
+// still works
+func (m Model) updateAlertDetails(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := m.alertPageHeight()
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.requestQuit()
+	case "esc", "!":
+		m.showAlerts = false
+	case "up", "k":
+		m.alertScroll--
+	case "down", "j":
+		m.alertScroll++
+	case "pgup":
+		m.alertScroll -= page
+	case "pgdown", " ":
+		m.alertScroll += page
+	case "home":
+		m.alertScroll = 0
+	case "end":
+		m.alertScroll = len(m.alertDetailLines())
+	}
+	m.alertScroll = max(0, min(m.alertScroll, len(m.alertDetailLines())-page))
+	return m, nil
+}
+
+// alertPageHeight is how many lines of alert text fit on screen, leaving
+// room for the header and the scroll hint
+func (m Model) alertPageHeight() int {
+	return max(8, m.height-10)
+}
+
+// alertDetailLines lays out every active alert as wrapped lines: its event,
+// severity and time left, then the headline and full description
+func (m Model) alertDetailLines() []string {
+	width := max(40, min(alertDetailsWidth, m.width-6))
+	wrap := lipgloss.NewStyle().Width(width)
+	detail := config.HelpStyle
+
+	var lines []string
+	for i, alert := range m.activeAlerts() {
+		if i > 0 {
+			lines = append(lines, "", detail.Render(strings.Repeat("─", width)), "")
+		}
+
+		emoji, color, _ := weather.GetAlertDisplay([]weather.Alert{alert})
+		lines = append(lines, lipgloss.NewStyle().Foreground(color).Bold(true).Render(emoji+" "+alert.Event))
+
+		meta := []string{}
+		if alert.Severity != "" {
+			meta = append(meta, "Severity: "+alert.Severity)
+		}
+		if alert.Urgency != "" {
+			meta = append(meta, "Urgency: "+alert.Urgency)
+		}
+		if !alert.Expires.IsZero() {
+			meta = append(meta, fmt.Sprintf("Expires in %s (%s)", formatTimeLeft(time.Until(alert.Expires)), m.formatClock(alert.Expires, "Jan 2 15:04")))
+		}
+		if len(meta) > 0 {
+			lines = append(lines, detail.Render(strings.Join(meta, " • ")))
+		}
+
+		if alert.Headline != "" {
+			lines = append(lines, "")
+			lines = append(lines, strings.Split(wrap.Bold(true).Render(alert.Headline), "\n")...)
+		}
+		if description := strings.TrimSpace(alert.Description); description != "" {
+			lines = append(lines, "")
+			lines = append(lines, strings.Split(wrap.Render(description), "\n")...)
+		}
+	}
+	return lines
+}
+
+// formatTimeLeft renders a countdown to the minute, as "2h 05m" or "12m"
+// (private helper)
+func formatTimeLeft(d time.Duration) string {
+	minutes := max(0, int(d.Round(time.Minute).Minutes()))
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// renderAlertDetails draws the page of alert text scrolled to, with a hint
+// saying where it is
+func (m Model) renderAlertDetails() string {
+	lines := m.alertDetailLines()
+	page := m.alertPageHeight()
+	start := max(0, min(m.alertScroll, len(lines)-page))
+	end := min(len(lines), start+page)
+
+	heading := "⚠️ 1 active alert"
+	if count := len(m.activeAlerts()); count != 1 {
+		heading = fmt.Sprintf("⚠️ %d active alerts", count)
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(config.PrimaryColor).Render(heading)
+	hint := config.HelpStyle.Render("[↑/↓] Scroll • [PgUp/PgDn] Page • [ESC] Back to radar")
+	if len(lines) > page {
+		hint = config.HelpStyle.Render(fmt.Sprintf("Lines %d-%d of %d • ", start+1, end, len(lines))) + hint
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", strings.Join(lines[start:end], "\n"), "", hint)
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// alertRotateInterval is how long each alert stays in the info panel when
+// more than one is active
+const alertRotateInterval = 4 * time.Second
+
+// AlertTickMsg moves the info panel on to the next active alert
+type AlertTickMsg time.Time
+
+func (m Model) tickAlerts() tea.Cmd {
+	return tea.Tick(alertRotateInterval, func(t time.Time) tea.Msg {
+		return AlertTickMsg(t)
+	})
+}
+
+// startAlertRotation starts cycling the info panel through the active alerts
+// when there's more than one, unless it's already cycling
+func (m Model) startAlertRotation() (Model, tea.Cmd) {
+	if m.alertRotating || len(m.activeAlerts()) < 2 {
+		return m, nil
+	}
+	m.alertRotating = true
+	return m, m.tickAlerts()
+}
+
+// rotatedAlerts is the order the info panel cycles through: the active alerts,
+// most severe first so each load opens on the worst one
+func (m Model) rotatedAlerts() []weather.Alert {
+	return weather.SortBySeverity(m.activeAlerts())
+}
+
+// shownAlert is the active alert the info panel is showing, with its position
+// and the number it cycles through; ok is false with no active alerts
+func (m Model) shownAlert() (alert weather.Alert, pos, count int, ok bool) {
+	alerts := m.rotatedAlerts()
+	if len(alerts) == 0 {
+		return weather.Alert{}, 0, 0, false
+	}
+	pos = m.alertTurn % len(alerts)
+	return alerts[pos], pos, len(alerts), true
+}
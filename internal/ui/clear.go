@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// clearOverlayText labels a loop with no precipitation in any frame
+const clearOverlayText = " No precipitation detected "
+
+// loopClear reports whether no frame in the loop found precipitation
+func (m Model) loopClear() bool {
+	for _, frame := range m.radar.Frames {
+		if frame.HasPrecip {
+			return false
+		}
+	}
+	return len(m.radar.Frames) > 0
+}
+
+// clearCaption returns the info panel line for a frame without
+// precipitation, or "" when there's nothing to say. Simulated frames are
+// already flagged as not real, so they get no caption.
+func (m Model) clearCaption() string {
+	if m.prefs.ClearNotice == config.ClearNoticeOff || !m.radar.IsRealData {
+		return ""
+	}
+	if m.currentFrame >= len(m.radar.Frames) || m.radar.Frames[m.currentFrame].HasPrecip {
+		return ""
+	}
+	if m.loopClear() {
+		return "☀ No precipitation anywhere in the loop - it's genuinely clear"
+	}
+	return "☀ No precipitation detected in this frame"
+}
+
+// drawClearOverlay labels the middle of the radar when the overlay notice is
+// on and the whole loop is clear
+func (m Model) drawClearOverlay(display [][]string) {
+	if m.prefs.ClearNotice != config.ClearNoticeOverlay || !m.radar.IsRealData || !m.loopClear() {
+		return
+	}
+
+	y := len(display) / 3
+	if y >= len(display) {
+		return
+	}
+	label := cells(lipgloss.NewStyle().Foreground(config.SuccessColor).Background(lipgloss.Color("235")), clearOverlayText)
+	left := (len(display[y]) - len(label)) / 2
+	for i, cell := range label {
+		if x := left + i; x >= 0 && x < len(display[y]) {
+			display[y][x] = cell
+		}
+	}
+}
+
+// jumpToPrecipFrame moves to the next (step 1) or previous (step -1) frame
+// with precipitation, wrapping around the loop. The current frame only
+// counts once every other frame has been checked.
+func (m Model) jumpToPrecipFrame(step int) Model {
+	n := len(m.radar.Frames)
+	for i := 1; i <= n; i++ {
+		idx := ((m.currentFrame+i*step)%n + n) % n
+		if m.radar.Frames[idx].HasPrecip {
+			if idx == m.currentFrame {
+				m.statusMsg = "No other frames with precipitation"
+			}
+			m.setFrame(idx)
+			return m
+		}
+	}
+	m.statusMsg = "No precipitation in any frame"
+	return m
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// utcSuffix marks clock times shown in UTC (Zulu)
+const utcSuffix = "Z"
+
+// clockZone returns the zone absolute times are shown in
+func (m Model) clockZone() *time.Location {
+	if m.prefs.UTC {
+		return time.UTC
+	}
+	return time.Local
+}
+
+// formatClock renders an absolute time with layout in the display zone,
+// suffixed with Z in UTC. Relative times ("5m ago") don't go through here.
+func (m Model) formatClock(t time.Time, layout string) string {
+	s := t.In(m.clockZone()).Format(layout)
+	if m.prefs.UTC {
+		s += utcSuffix
+	}
+	return s
+}
+
+// formatHour renders the hour of an absolute time: 3PM locally, 15Z in UTC
+func (m Model) formatHour(t time.Time) string {
+	if m.prefs.UTC {
+		return m.formatClock(t, "15")
+	}
+	return m.formatClock(t, "3PM")
+}
+
+// frameClock formats a frame time, with the date for replays of another day
+func (m Model) frameClock(t time.Time) string {
+	now := time.Now().In(m.clockZone())
+	if y, mo, d := t.In(m.clockZone()).Date(); y != now.Year() || mo != now.Month() || d != now.Day() {
+		return m.formatClock(t, "Jan 2 15:04")
+	}
+	return m.formatClock(t, "15:04")
+}
+
+// toggleUTC switches absolute times between local time and UTC and saves
+// the choice
+func (m Model) toggleUTC() (Model, tea.Cmd) {
+	utc := !m.prefs.UTC
+	cmd := m.setPreference(func(p *config.Preferences) {
+		p.UTC = utc
+	})
+	if utc {
+		m.statusMsg = "Times in UTC (Z) [Z for local]"
+	} else {
+		m.statusMsg = "Times in local time"
+	}
+	return m, cmd
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// toggleCompare pins the current frame on the left and puts the newest frame
+// on the right, pausing so both stay put. The right side then follows the
+// usual frame keys and the left steps with [ and ].
+func (m Model) toggleCompare() Model {
+	m.comparing = !m.comparing
+	if !m.comparing || len(m.radar.Frames) == 0 {
+		return m
+	}
+
+	m.compareFrame = m.currentFrame
+	m.setFrame(len(m.radar.Frames) - 1)
+	m.isPaused = true
+	m.animationActive = false
+	return m
+}
+
+// stepCompareFrame moves the pinned left frame, wrapping at either end
+func (m Model) stepCompareFrame(step int) Model {
+	if n := len(m.radar.Frames); n > 0 {
+		m.compareFrame = (m.pinnedFrame() + step + n) % n
+	}
+	return m
+}
+
+// pinnedFrame returns the left frame, kept within the loop if a refresh
+// shortened it
+func (m Model) pinnedFrame() int {
+	return max(0, min(m.compareFrame, len(m.radar.Frames)-1))
+}
+
+// renderCompare draws the pinned frame and the current frame side by side,
+// each labeled with its time
+func (m Model) renderCompare() string {
+	left, right := m.pinnedFrame(), m.currentFrame
+	leftFrame, rightFrame := m.radar.Frames[left], m.radar.Frames[right]
+
+	leftDisplay := m.newDisplay()
+	if leftFrame.Data != nil {
+		m.DrawPrecipitation(leftDisplay, leftFrame.Data)
+	}
+	rightDisplay := m.newDisplay()
+	if rightFrame.Data != nil {
+		m.DrawPrecipitation(rightDisplay, rightFrame.Data)
+	}
+	m.drawAlertPolygons(leftDisplay)
+	m.drawAlertPolygons(rightDisplay)
+
+	gap := rightFrame.Timestamp.Sub(leftFrame.Timestamp).Round(time.Minute)
+	var change string
+	switch {
+	case gap > 0:
+		change = fmt.Sprintf("%s later", gap)
+	case gap < 0:
+		change = fmt.Sprintf("%s earlier", -gap)
+	default:
+		change = "same time"
+	}
+
+	label := lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderRadarPanel(leftDisplay, label.Render(fmt.Sprintf("[ ] Frame %d • %s", left+1, m.frameClock(leftFrame.Timestamp)))),
+		m.renderRadarPanel(rightDisplay, label.Render(fmt.Sprintf("←/→ Frame %d • %s • %s", right+1, m.frameClock(rightFrame.Timestamp), change))),
+	)
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// renderObservationDetails draws the observation's humidity, dew point and
+// pressure in one line under the temperature, leaving out any the station
+// didn't report, or nothing when it reported none of them
+func (m Model) renderObservationDetails() string {
+	var parts []string
+	if humidity := m.radar.Humidity; humidity != nil {
+		parts = append(parts, fmt.Sprintf("Humidity %.0f%%", *humidity))
+	}
+	if dewpoint := m.radar.DewpointC; dewpoint != nil {
+		parts = append(parts, "Dew point "+m.formatTemp(*dewpoint))
+	}
+	if pressure := m.radar.PressurePa; pressure != nil {
+		parts = append(parts, "Pressure "+m.formatPressure(*pressure))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return config.HelpStyle.Render("💧 " + strings.Join(parts, " • "))
+}
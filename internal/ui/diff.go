@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// Diverging palette for diff mode, weakest change first: warm for growth,
+// cool for decay
+var (
+	growthColors = []lipgloss.Color{
+		lipgloss.Color("220"), lipgloss.Color("214"), lipgloss.Color("202"), lipgloss.Color("196"),
+	}
+	decayColors = []lipgloss.Color{
+		lipgloss.Color("117"), lipgloss.Color("75"), lipgloss.Color("33"), lipgloss.Color("21"),
+	}
+	diffChars = []string{"·", "∘", "○", "●"}
+)
+
+// diffLevel buckets the size of an intensity change into a palette index
+func diffLevel(change int) int {
+	switch {
+	case change >= 6:
+		return 3
+	case change >= 4:
+		return 2
+	case change >= 2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DrawDiff draws the change in intensity between two frames in place of
+// the precipitation itself; unchanged cells leave the map showing
+func (m Model) DrawDiff(display [][]string, diff [][]int) {
+	for y := 0; y < len(diff) && y < config.RadarHeight; y++ {
+		for x := 0; x < len(diff[y]) && x < config.RadarWidth; x++ {
+			change := diff[y][x]
+			switch {
+			case change > 0:
+				level := diffLevel(change)
+				display[y][x] = lipgloss.NewStyle().Foreground(growthColors[level]).Render(diffChars[level])
+			case change < 0:
+				level := diffLevel(-change)
+				display[y][x] = lipgloss.NewStyle().Foreground(decayColors[level]).Render(diffChars[level])
+			}
+		}
+	}
+}
+
+// renderDiffFrame draws how the current frame differs from the one before it
+func (m Model) renderDiffFrame() string {
+	return m.renderRadarPanel(m.diffGrid())
+}
+
+// diffGrid draws the changes since the previous frame and the overlays on
+// the radar grid, returning it with the key to show under it
+func (m Model) diffGrid() ([][]string, string) {
+	display := m.newDisplay()
+
+	legend := config.HelpStyle.Render("First frame - step forward to see changes")
+	if m.currentFrame > 0 {
+		prev, frame := m.radar.Frames[m.currentFrame-1], m.radar.Frames[m.currentFrame]
+		m.DrawDiff(display, radar.FrameDiff(prev, frame))
+		legend = m.renderDiffLegend()
+	}
+
+	m.drawAlertPolygons(display)
+	m.drawStormMarker(display)
+	m.drawLocator(display)
+
+	return display, legend
+}
+
+// renderDiffLegend renders a one-line decay-to-growth key
+func (m Model) renderDiffLegend() string {
+	label := config.HelpStyle
+	legend := label.Render("Change since last frame: weakening ")
+	for i := len(diffChars) - 1; i >= 0; i-- {
+		legend += lipgloss.NewStyle().Foreground(decayColors[i]).Render(diffChars[i])
+	}
+	legend += " "
+	for i := range diffChars {
+		legend += lipgloss.NewStyle().Foreground(growthColors[i]).Render(diffChars[i])
+	}
+	return legend + label.Render(" growing")
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// exportedMsg reports where an export was written, or why it couldn't be
+type exportedMsg struct {
+	// What names the export in the status line, such as "loop"
+	What string
+	Path string
+	Err  error
+}
+
+// exportsOffMsg is the status shown when a session can't save exports
+const exportsOffMsg = "Saving is turned off on this server"
+
+// WithExports allows or refuses saving the loop or frame to files. The SSH
+// server turns exports off so visitors can't fill its disk.
+func (m Model) WithExports(enabled bool) Model {
+	m.exportsOff = !enabled
+	return m
+}
+
+// writeExport writes an export to a new file in the working directory named
+// for the current time with the given extension. A random suffix keeps two
+// exports in the same second from overwriting each other, and a failed export
+// leaves no partial file behind. (private helper)
+func writeExport(what, ext string, write func(io.Writer) error) tea.Msg {
+	file, err := os.CreateTemp(".", fmt.Sprintf("termidar-%s-*.%s", time.Now().Format("20060102-150405"), ext))
+	if err != nil {
+		return exportedMsg{What: what, Err: err}
+	}
+	fail := func(err error) tea.Msg {
+		file.Close()
+		os.Remove(file.Name())
+		return exportedMsg{What: what, Err: err}
+	}
+
+	path, err := filepath.Abs(file.Name())
+	if err != nil {
+		return fail(err)
+	}
+	// Exports are for sharing, so don't keep CreateTemp's owner-only mode
+	if err := file.Chmod(0o644); err != nil {
+		return fail(err)
+	}
+	if err := write(file); err != nil {
+		return fail(err)
+	}
+	if err := file.Close(); err != nil {
+		return fail(err)
+	}
+	return exportedMsg{What: what, Path: path}
+}
+
+// exportGrid is the radar grid the text export captures: the single view on
+// screen, or the current frame's reflectivity in the side-by-side views
+func (m Model) exportGrid() [][]string {
+	if !m.comparing && !m.splitProducts {
+		if m.velocityView {
+			grid, _ := m.velocityGrid()
+			return grid
+		}
+		if m.diffMode {
+			grid, _ := m.diffGrid()
+			return grid
+		}
+	}
+	return m.radarGrid()
+}
+
+// saveFrameText writes the radar grid on screen to a text file under a line
+// naming the place and time, keeping its ANSI colors unless the text export
+// is set to plain
+func (m Model) saveFrameText() tea.Cmd {
+	grid := m.exportGrid()
+	caption := "Radar at " + m.formatClock(m.radar.Frames[m.currentFrame].Timestamp, "Jan 2 15:04")
+	if !m.private {
+		caption = strings.Replace(caption, "Radar", "Radar for "+m.radar.Location, 1)
+	}
+	colors := m.prefs.TextExportANSI
+
+	return func() tea.Msg {
+		var text strings.Builder
+		text.WriteString(caption + "\n")
+		for _, row := range grid {
+			line := strings.Join(row, "")
+			if !colors {
+				line = ansi.Strip(line)
+			}
+			text.WriteString(line + "\n")
+		}
+		return writeExport("frame", "txt", func(w io.Writer) error {
+			_, err := io.WriteString(w, text.String())
+			return err
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/N-Erickson/termidar/internal/radar"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// explainError puts a load error in plain words: a headline saying what went
+// wrong and guidance on what to do about it. Errors that don't fall into a
+// known kind are shown as they came.
+func (m Model) explainError(failure radar.Failure, raw string) (string, string) {
+	switch failure {
+	case radar.FailureInvalid:
+		return "That doesn't look like a location",
+			`Enter a 5-digit ZIP code, a 3-digit ZIP prefix or a place like "Boulder, CO"`
+	case radar.FailureNotFound:
+		return fmt.Sprintf("Couldn't find %q", m.redactQuery(m.zipCode)),
+			"Check the ZIP code, or the spelling of the city and state"
+	case radar.FailureUnsupportedPrefix:
+		prefixes := weather.ZipPrefixes()
+		return fmt.Sprintf("No region for ZIP prefix %q", m.redactQuery(m.zipCode)),
+			fmt.Sprintf("Only the prefixes of built-in cities have one, such as %s. Enter a 5-digit ZIP code for anywhere else.",
+				strings.Join(prefixes[:min(5, len(prefixes))], ", "))
+	case radar.FailureNetwork:
+		return "Can't reach the weather services", m.reconnectNotice()
+	case radar.FailureNoCoverage:
+		if !m.replayTime.IsZero() {
+			return "No archived radar for that time",
+				"Try another date and time, or the live loop"
+		}
+		return "No radar coverage here right now",
+			"Try a nearby location, or another radar source in settings"
+	}
+	return m.redactQuery(raw), ""
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// newNoteInput creates the editor for a favorite's note
+func newNoteInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. kids' school, cabin"
+	ti.CharLimit = config.MaxNoteLength
+	ti.Width = config.MaxNoteLength
+	ti.Prompt = "📝 "
+	return ti
+}
+
+// toggleFavorite saves the location on screen as a favorite, or removes it
+// if it already is one
+func (m Model) toggleFavorite() (Model, tea.Cmd) {
+	m.favorites = m.store.LoadFavorites()
+	if i := config.FindFavorite(m.favorites, m.zipCode); i >= 0 {
+		m.favorites = append(m.favorites[:i], m.favorites[i+1:]...)
+		m.statusMsg = "Removed from favorites"
+	} else {
+		m.favorites = append(m.favorites, config.Favorite{Query: m.zipCode, Location: m.radar.Location})
+		m.statusMsg = "Saved to favorites - add a note with Ctrl+F on the location screen"
+	}
+	return m, m.saveFavorites()
+}
+
+// saveFavorites writes the favorites in the background; failures are
+// ignored like preference saves
+func (m Model) saveFavorites() tea.Cmd {
+	store, favorites := m.store, append([]config.Favorite(nil), m.favorites...)
+	return func() tea.Msg {
+		_ = store.SaveFavorites(favorites)
+		return nil
+	}
+}
+
+// favoriteNote returns the note saved with the location on screen, unless
+// private mode hides it
+func (m Model) favoriteNote() string {
+	if m.private {
+		return ""
+	}
+	if i := config.FindFavorite(m.favorites, m.zipCode); i >= 0 {
+		return m.favorites[i].Note
+	}
+	return ""
+}
+
+// openFavorites switches to the favorites list from the location input
+func (m Model) openFavorites() Model {
+	m.state = StateFavorites
+	m.favorites = m.store.LoadFavorites()
+	m.favoritesCursor = 0
+	m.editingNote = false
+	m.zipInput.Blur()
+	return m
+}
+
+// closeFavorites returns to the location input
+func (m Model) closeFavorites() (tea.Model, tea.Cmd) {
+	m.state = StateInput
+	m.editingNote = false
+	m.zipInput.Focus()
+	return m, textinput.Blink
+}
+
+// updateFavorites handles key presses on the favorites list
+func (m Model) updateFavorites(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.editingNote {
+		return m.updateNoteEditor(msg)
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
+	case "esc", "q":
+		return m.closeFavorites()
+	}
+	if len(m.favorites) == 0 || m.private {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.favoritesCursor = (m.favoritesCursor - 1 + len(m.favorites)) % len(m.favorites)
+	case "down", "j":
+		m.favoritesCursor = (m.favoritesCursor + 1) % len(m.favorites)
+	case "enter":
+		m.zipCode = m.favorites[m.favoritesCursor].Query
+		m.editingNote = false
+		return m.startLoad()
+	case "e":
+		m.editingNote = true
+		m.noteInput.SetValue(m.favorites[m.favoritesCursor].Note)
+		m.noteInput.CursorEnd()
+		return m, m.noteInput.Focus()
+	case "d", "delete":
+		m.favorites = append(m.favorites[:m.favoritesCursor], m.favorites[m.favoritesCursor+1:]...)
+		m.favoritesCursor = min(m.favoritesCursor, max(0, len(m.favorites)-1))
+		return m, m.saveFavorites()
+	}
+	return m, nil
+}
+
+// updateNoteEditor handles key presses while a note is being edited: Enter
+// saves it and ESC leaves it as it was
+func (m Model) updateNoteEditor(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
+	case "esc":
+		m.editingNote = false
+		m.noteInput.Blur()
+		return m, nil
+	case "enter":
+		m.editingNote = false
+		m.noteInput.Blur()
+		m.favorites[m.favoritesCursor].Note = strings.TrimSpace(m.noteInput.Value())
+		return m, m.saveFavorites()
+	}
+
+	var cmd tea.Cmd
+	m.noteInput, cmd = m.noteInput.Update(msg)
+	return m, cmd
+}
+
+// renderFavorites lists the saved locations with their notes. Like the recent
+// locations, the list is hidden in private mode, since it gives away where
+// you care about.
+func (m Model) renderFavorites() string {
+	if m.private {
+		box := config.ActiveInputStyle.Render("Favorites are hidden in private mode")
+		return lipgloss.JoinVertical(lipgloss.Left, "⭐ Favorites", box, config.HelpStyle.Render("ESC Return"))
+	}
+	if len(m.favorites) == 0 {
+		box := config.ActiveInputStyle.Render("No favorites yet - press * while viewing a location to save it")
+		return lipgloss.JoinVertical(lipgloss.Left, "⭐ Favorites", box, config.HelpStyle.Render("ESC Return"))
+	}
+
+	selected := lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true)
+	note := config.HelpStyle
+	var rows []string
+	for i, favorite := range m.favorites {
+		row := fmt.Sprintf("  %s (%s)", favorite.Location, favorite.Query)
+		if i == m.favoritesCursor {
+			row = selected.Render("▸" + row[1:])
+		}
+		rows = append(rows, row)
+
+		switch {
+		case i == m.favoritesCursor && m.editingNote:
+			rows = append(rows, "    "+m.noteInput.View())
+		case favorite.Note != "":
+			rows = append(rows, note.Render("    📝 "+favorite.Note))
+		}
+	}
+
+	help := "↑/↓ Select • Enter View • E Edit note • D Remove • ESC Return"
+	if m.editingNote {
+		help = "Enter Save note • ESC Cancel"
+	}
+
+	box := config.ActiveInputStyle.Render(strings.Join(rows, "\n"))
+	return lipgloss.JoinVertical(lipgloss.Left, "⭐ Favorites", box, config.HelpStyle.Render(help))
+}
@@ -0,0 +1,200 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/geography"
+	"github.com/N-Erickson/termidar/internal/radar"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// Follow mode limits each re-center to a fraction of the view so a bad match
+// can't fling the map across the country
+const (
+	followMaxPanX = config.RadarWidth / 4
+	followMaxPanY = config.RadarHeight / 4
+)
+
+// defaultFrameSpacing is assumed when a loop has too few frames to measure
+const defaultFrameSpacing = 5 * time.Minute
+
+// viewProjection returns the projection of the loaded view, zoomed out for
+// a region
+func (m Model) viewProjection() geography.Projection {
+	return geography.NewProjection(m.radar.Lat, m.radar.Lon, config.RadarWidth/2, config.RadarHeight/2).Zoomed(m.radar.Scale)
+}
+
+// toggleFollow engages follow mode on the storm nearest the center, or turns
+// it off and pans back to the searched location
+func (m Model) toggleFollow() (Model, tea.Cmd) {
+	if m.following {
+		m.following = false
+		m.stormTrack = nil
+		if m.viewCenter == nil {
+			return m, nil
+		}
+		m.viewCenter = nil
+		m.statusMsg = "Stopped following - returning to your location"
+		return m.reloadInBackground()
+	}
+	return m.lockStorm(false)
+}
+
+// lockStorm picks a storm cell in the newest frame to follow. With next set it
+// moves on to the next largest cell after the one currently tracked.
+func (m Model) lockStorm(next bool) (Model, tea.Cmd) {
+	if len(m.radar.Frames) == 0 {
+		return m, nil
+	}
+
+	cells := radar.FindStormCells(m.radar.Frames[len(m.radar.Frames)-1].Data)
+	if len(cells) == 0 {
+		m.statusMsg = "No storm cells to follow"
+		return m, nil
+	}
+
+	var target radar.StormCell
+	if next && m.following && len(m.stormTrack) > 0 {
+		// Cells are ordered largest first; take the one after the current
+		current := m.stormTrack[len(m.stormTrack)-1].Cell
+		target = cells[0]
+		for i, cell := range cells {
+			if cell.X == current.X && cell.Y == current.Y {
+				target = cells[(i+1)%len(cells)]
+				break
+			}
+		}
+	} else {
+		// Prefer the storm closest to the middle of the view, which is
+		// usually the one bearing down on the user
+		cx, cy := float64(config.RadarWidth/2), float64(config.RadarHeight/2)
+		var ok bool
+		target, ok = radar.NearestCell(cells, cx, cy, math.Inf(1))
+		if !ok {
+			target = cells[0]
+		}
+	}
+
+	m.following = true
+	m.alertIndex = -1
+	m.stormTrack = radar.TrackStorm(m.radar.Frames, target.X, target.Y)
+	m.trackLat, m.trackLon = m.viewProjection().ToLatLon(int(math.Round(target.X)), int(math.Round(target.Y)))
+	m.statusMsg = fmt.Sprintf("Following storm cell (%d cells, peak %d)", target.Cells, target.Peak)
+
+	m.viewCenter = m.projectedStormCenter()
+	return m.reloadInBackground()
+}
+
+// updateFollow re-acquires the tracked storm in a freshly loaded loop and
+// aims the next load at where it is heading
+func (m Model) updateFollow() Model {
+	if !m.following || len(m.radar.Frames) == 0 {
+		return m
+	}
+
+	x, y := m.viewProjection().ToDisplay(m.trackLat, m.trackLon)
+	track := radar.TrackStorm(m.radar.Frames, float64(x), float64(y))
+	if len(track) == 0 {
+		m.following = false
+		m.stormTrack = nil
+		m.statusMsg = "Lost the tracked storm - press F to follow another"
+		return m
+	}
+
+	m.stormTrack = track
+	last := track[len(track)-1].Cell
+	m.trackLat, m.trackLon = m.viewProjection().ToLatLon(int(math.Round(last.X)), int(math.Round(last.Y)))
+	m.viewCenter = m.projectedStormCenter()
+	return m
+}
+
+// projectedStormCenter estimates where the tracked storm will be at the next
+// refresh, limited to a fraction of the view
+func (m Model) projectedStormCenter() *weather.Location {
+	if len(m.stormTrack) == 0 {
+		return m.viewCenter
+	}
+
+	last := m.stormTrack[len(m.stormTrack)-1].Cell
+	dx, dy := radar.StormMotion(m.stormTrack)
+	ahead := float64(m.refreshInterval) / float64(m.frameSpacing())
+
+	centerX, centerY := config.RadarWidth/2, config.RadarHeight/2
+	x := int(math.Round(last.X + dx*ahead))
+	y := int(math.Round(last.Y + dy*ahead))
+	x = max(centerX-followMaxPanX, min(centerX+followMaxPanX, x))
+	y = max(centerY-followMaxPanY, min(centerY+followMaxPanY, y))
+
+	lat, lon := m.viewProjection().ToLatLon(x, y)
+	return &weather.Location{Lat: lat, Lon: lon}
+}
+
+// frameSpacing returns the time between the last two frames of the loop
+func (m Model) frameSpacing() time.Duration {
+	frames := m.radar.Frames
+	if len(frames) < 2 {
+		return defaultFrameSpacing
+	}
+	spacing := frames[len(frames)-1].Timestamp.Sub(frames[len(frames)-2].Timestamp)
+	if spacing <= 0 {
+		return defaultFrameSpacing
+	}
+	return spacing
+}
+
+// reloadInBackground fetches the loop for the current view options while the
+// existing one keeps playing
+func (m Model) reloadInBackground() (Model, tea.Cmd) {
+	m.isBackgroundRefresh = true
+	m.loadStarted = time.Now()
+	return m, radar.LoadData(m.zipCode, m.loadOptions())
+}
+
+// drawStormMarker marks the tracked storm's position in the current frame
+func (m Model) drawStormMarker(display [][]string) {
+	if !m.following {
+		return
+	}
+	for _, point := range m.stormTrack {
+		if point.Frame != m.currentFrame {
+			continue
+		}
+		x, y := int(math.Round(point.Cell.X)), int(math.Round(point.Cell.Y))
+		if y >= 0 && y < len(display) && x >= 0 && x < len(display[y]) {
+			display[y][x] = lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true).Render("◎")
+		}
+		return
+	}
+}
+
+// stormMotionSummary describes the tracked storm's heading and speed
+func (m Model) stormMotionSummary() string {
+	dx, dy := radar.StormMotion(m.stormTrack)
+	proj := m.viewProjection()
+
+	milesEast := dx * proj.MilesPerCellX
+	milesNorth := -dy * proj.MilesPerCellY
+	mph := math.Hypot(milesEast, milesNorth) / m.frameSpacing().Hours()
+	if mph < 1 {
+		return "Following storm • nearly stationary"
+	}
+
+	bearing := math.Atan2(milesEast, milesNorth) * 180 / math.Pi
+	if bearing < 0 {
+		bearing += 360
+	}
+	directions := []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+	heading := directions[int(math.Round(bearing/45))%len(directions)]
+
+	speed := fmt.Sprintf("%.0f mph", mph)
+	if m.metric() {
+		speed = fmt.Sprintf("%.0f km/h", mph*kmPerMile)
+	}
+	return fmt.Sprintf("Following storm • moving %s at %s", heading, speed)
+}
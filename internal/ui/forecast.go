@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// forecastColumnWidth is the width of each period in the forecast strip,
+// which fits weather.ForecastPeriods under the radar
+const forecastColumnWidth = 16
+
+// forecastLoadedMsg carries the forecast fetched after the radar loaded
+type forecastLoadedMsg struct {
+	Forecast []weather.ForecastPeriod
+}
+
+// fetchForecast loads the forecast on its own, so turning the strip on
+// never slows the radar load
+func fetchForecast(lat, lon float64) tea.Cmd {
+	return func() tea.Msg {
+		forecast, _ := weather.FetchForecast(context.Background(), lat, lon)
+		return forecastLoadedMsg{Forecast: forecast}
+	}
+}
+
+// loadForecast starts fetching the forecast for the searched location when
+// the strip is on
+func (m *Model) loadForecast() tea.Cmd {
+	if !m.prefs.Forecast {
+		return nil
+	}
+	m.forecastLoading = true
+	return fetchForecast(m.radar.HomeLat, m.radar.HomeLon)
+}
+
+// renderForecast draws the coming periods of the forecast side by side: the
+// period's name, its high or low, and the short forecast
+func (m Model) renderForecast() string {
+	label := config.HelpStyle
+	if len(m.forecast) == 0 {
+		if m.forecastLoading {
+			return label.Render("Forecast: loading...")
+		}
+		return label.Render("Forecast: unavailable")
+	}
+
+	column := lipgloss.NewStyle().Width(forecastColumnWidth).PaddingRight(1)
+	var columns []string
+	for _, period := range m.forecast {
+		columns = append(columns, column.Render(lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Bold(true).Render(period.Name),
+			lipgloss.NewStyle().Foreground(m.tempColor(period.Celsius)).Render(m.formatTemp(period.Celsius)),
+			label.Render(period.Short),
+		)))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
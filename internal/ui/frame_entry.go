@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxFrameEntryDigits bounds how many digits the frame jump prompt accepts
+const maxFrameEntryDigits = 3
+
+// startFrameEntry opens the frame jump prompt seeded with the first digit typed
+func (m Model) startFrameEntry(digit string) Model {
+	m.frameEntryActive = true
+	m.frameEntry = digit
+	return m
+}
+
+// updateFrameEntry handles key presses while the frame jump prompt is open
+func (m Model) updateFrameEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
+	case "esc":
+		m.frameEntryActive = false
+		m.frameEntry = ""
+	case "backspace":
+		if len(m.frameEntry) > 0 {
+			m.frameEntry = m.frameEntry[:len(m.frameEntry)-1]
+		}
+		if m.frameEntry == "" {
+			m.frameEntryActive = false
+		}
+	case "enter":
+		m = m.jumpToEnteredFrame()
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if len(m.frameEntry) < maxFrameEntryDigits {
+			m.frameEntry += msg.String()
+		}
+	}
+
+	return m, nil
+}
+
+// jumpToEnteredFrame moves to the frame typed into the prompt, clamped to the
+// loaded range, and pauses so the chosen frame stays on screen
+func (m Model) jumpToEnteredFrame() Model {
+	entry := m.frameEntry
+	m.frameEntryActive = false
+	m.frameEntry = ""
+
+	total := len(m.radar.Frames)
+	if total == 0 {
+		return m
+	}
+
+	n, err := strconv.Atoi(entry)
+	if err != nil || n < 1 {
+		m.statusMsg = fmt.Sprintf("Invalid frame %q - enter 1-%d", entry, total)
+		return m
+	}
+
+	if n > total {
+		m.statusMsg = fmt.Sprintf("Only %d frames loaded - showing the last one", total)
+		n = total
+	}
+
+	m.setFrame(n - 1)
+	m.isPaused = true
+	return m
+}
+
+// renderFrameEntry renders the frame jump prompt shown in place of the controls
+func (m Model) renderFrameEntry() string {
+	return fmt.Sprintf("Go to frame: %s_  (1-%d, Enter to jump, Esc to cancel)",
+		m.frameEntry, len(m.radar.Frames))
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// Pixels drawn for each radar cell, close to the cell's ground size so the
+// exported map keeps its proportions
+const (
+	gifCellWidth  = 10
+	gifCellHeight = 12
+)
+
+// gifMarker is the palette index of the location marker, after the
+// precipitation ramp
+var gifMarker = uint8(len(precipColors))
+
+// gifPalette returns the precipitation ramp as image colors, followed by
+// white for the location marker (private helper)
+func gifPalette() color.Palette {
+	palette := make(color.Palette, 0, len(precipColors)+1)
+	for _, c := range precipColors {
+		code, _ := strconv.Atoi(string(c))
+		palette = append(palette, ansi.IndexedColor(code))
+	}
+	return append(palette, color.White)
+}
+
+// gifFrame draws one frame's intensities as an image in the colors the
+// radar shows them in (private helper)
+func (m Model) gifFrame(data [][]int, palette color.Palette) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, config.RadarWidth*gifCellWidth, config.RadarHeight*gifCellHeight), palette)
+	fill := func(x, y int, index uint8) {
+		for py := y * gifCellHeight; py < (y+1)*gifCellHeight; py++ {
+			for px := x * gifCellWidth; px < (x+1)*gifCellWidth; px++ {
+				img.SetColorIndex(px, py, index)
+			}
+		}
+	}
+
+	for y := 0; y < len(data) && y < config.RadarHeight; y++ {
+		for x := 0; x < len(data[y]) && x < config.RadarWidth; x++ {
+			if intensity := data[y][x]; m.drawsIntensity(intensity) && intensity < len(precipColors) {
+				fill(x, y, uint8(m.displayLevel(intensity)))
+			}
+		}
+	}
+	if !m.private {
+		if x, y := m.homeCell(); x >= 0 && x < config.RadarWidth && y >= 0 && y < config.RadarHeight {
+			fill(x, y, gifMarker)
+		}
+	}
+	return img
+}
+
+// saveGIF exports the loop as an animated GIF in the working directory,
+// named for the current time and never replacing an earlier export. Frames
+// play at the loop's speed and the newest holds as it does on screen.
+func (m Model) saveGIF() tea.Cmd {
+	return func() tea.Msg {
+		palette := gifPalette()
+		anim := &gif.GIF{}
+		for i, frame := range m.radar.Frames {
+			delay := m.frameRate
+			if i == len(m.radar.Frames)-1 {
+				delay = max(delay, m.prefs.HoldLast())
+			}
+			anim.Image = append(anim.Image, m.gifFrame(frame.Data, palette))
+			anim.Delay = append(anim.Delay, int(delay/(10*time.Millisecond)))
+		}
+
+		return writeExport("loop", "gif", func(w io.Writer) error {
+			return gif.EncodeAll(w, anim)
+		})
+	}
+}
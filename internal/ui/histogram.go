@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// histogramBin groups a range of intensities under one bar
+type histogramBin struct {
+	label string
+	max   int // highest intensity in the bin
+	color lipgloss.Color
+}
+
+// histogramBins split the precipitation ramp into light through severe
+var histogramBins = []histogramBin{
+	{"L", 3, precipColors[2]},
+	{"M", 6, precipColors[5]},
+	{"H", 8, precipColors[8]},
+	{"S", 10, precipColors[10]},
+}
+
+// Histogram box layout, drawn in the top-right corner of the radar
+const (
+	histogramBarWidth = 8
+	histogramWidth    = 17
+)
+
+// barEighths are partial block characters in eighths of a cell
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// intensityHistogram counts precipitating cells in each bin and returns the
+// counts with the total number of cells in the frame
+func intensityHistogram(data [][]int) ([]int, int) {
+	counts := make([]int, len(histogramBins))
+	total := 0
+	for _, row := range data {
+		for _, intensity := range row {
+			total++
+			if intensity <= 0 {
+				continue
+			}
+			for i, bin := range histogramBins {
+				if intensity <= bin.max || i == len(histogramBins)-1 {
+					counts[i]++
+					break
+				}
+			}
+		}
+	}
+	return counts, total
+}
+
+// drawHistogram overlays the intensity distribution of the current frame,
+// with bars scaled to the largest bin and each labeled by its share of the
+// precipitating cells
+func (m Model) drawHistogram(display [][]string) {
+	frame := m.radar.Frames[m.currentFrame]
+	counts, total := intensityHistogram(frame.Data)
+
+	precip, largest := 0, 0
+	for _, count := range counts {
+		precip += count
+		largest = max(largest, count)
+	}
+
+	label := config.HelpStyle
+	lines := [][]string{cells(label, fmt.Sprintf(" %3.0f%% coverage", percentOf(precip, total)))}
+	for i, bin := range histogramBins {
+		bar := ""
+		if largest > 0 {
+			eighths := int(math.Round(float64(counts[i]) / float64(largest) * histogramBarWidth * 8))
+			for ; eighths >= 8; eighths -= 8 {
+				bar += "█"
+			}
+			bar += barEighths[eighths]
+		}
+
+		row := cells(label, " "+bin.label+" ")
+		row = append(row, cells(lipgloss.NewStyle().Foreground(bin.color), bar)...)
+		row = append(row, cells(label, fmt.Sprintf("%*s", histogramBarWidth-len([]rune(bar))+1, ""))...)
+		row = append(row, cells(label, fmt.Sprintf("%3.0f%%", percentOf(counts[i], precip)))...)
+		lines = append(lines, row)
+	}
+
+	left := config.RadarWidth - histogramWidth
+	for y, row := range lines {
+		if y >= len(display) {
+			break
+		}
+		for x := 0; x < histogramWidth && left+x < len(display[y]); x++ {
+			display[y][left+x] = " "
+			if x < len(row) {
+				display[y][left+x] = row[x]
+			}
+		}
+	}
+}
+
+// cells splits text into styled single-character display cells
+func cells(style lipgloss.Style, text string) []string {
+	var out []string
+	for _, r := range text {
+		out = append(out, style.Render(string(r)))
+	}
+	return out
+}
+
+// percentOf returns n as a percentage of total, or zero when total is zero
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// sparkBlocks are the bar heights of the hourly sparklines, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// fetchHourly loads the hourly forecast on its own when the outlook is
+// switched on
+func fetchHourly(lat, lon float64) tea.Cmd {
+	return func() tea.Msg {
+		hourly, _ := weather.FetchHourlyForecast(context.Background(), lat, lon)
+		return hourlyLoadedMsg{Hourly: hourly}
+	}
+}
+
+// sparkBlock picks the bar for value within low..high
+func sparkBlock(value, low, high float64) rune {
+	if high <= low {
+		return sparkBlocks[len(sparkBlocks)/2]
+	}
+	i := int((value - low) / (high - low) * float64(len(sparkBlocks)-1))
+	return sparkBlocks[max(0, min(len(sparkBlocks)-1, i))]
+}
+
+// renderHourly draws the next hours as a temperature sparkline scaled to
+// the day's range and a precipitation chance bar scaled to 0-100%
+func (m Model) renderHourly() string {
+	label := config.HelpStyle
+	hourly := m.radar.Hourly
+	if len(hourly) == 0 {
+		if m.hourlyLoading {
+			return label.Render("Next 12h: loading hourly forecast...")
+		}
+		return label.Render("Next 12h: hourly forecast unavailable")
+	}
+
+	low, high := hourly[0].Celsius, hourly[0].Celsius
+	for _, period := range hourly {
+		low = min(low, period.Celsius)
+		high = max(high, period.Celsius)
+	}
+
+	var temps, rain strings.Builder
+	peak := 0
+	for _, period := range hourly {
+		temps.WriteString(lipgloss.NewStyle().Foreground(m.tempColor(period.Celsius)).
+			Render(string(sparkBlock(period.Celsius, low, high))))
+
+		if period.PrecipChance == nil {
+			rain.WriteString(label.Render("·"))
+			continue
+		}
+		chance := *period.PrecipChance
+		peak = max(peak, chance)
+		rain.WriteString(lipgloss.NewStyle().Foreground(config.RadarGreen).
+			Render(string(sparkBlock(float64(chance), 0, 100))))
+	}
+
+	start := m.formatHour(hourly[0].Start)
+	end := m.formatHour(hourly[len(hourly)-1].Start)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		label.Render(fmt.Sprintf("Next %dh (%s-%s)", len(hourly), start, end)),
+		label.Render("Temp ")+temps.String()+label.Render(fmt.Sprintf(" %s to %s", m.formatTemp(low), m.formatTemp(high))),
+		label.Render("Rain ")+rain.String()+label.Render(fmt.Sprintf(" up to %d%%", peak)),
+	)
+}
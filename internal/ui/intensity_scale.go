@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// logIntensityLevels maps each decoded intensity to the display level used
+// by the log scale. The buckets step through reflectivity, which is already
+// logarithmic, so rain rate grows exponentially across them: the light
+// buckets share the faintest levels and the heavy ones spread over the top.
+var logIntensityLevels = func() []int {
+	const steps = 4.0 // doublings of rain rate across the scale
+	levels := make([]int, len(precipChars))
+	top := len(precipChars) - 1
+	for i := 1; i <= top; i++ {
+		rate := (math.Exp2(steps*float64(i)/float64(top)) - 1) / (math.Exp2(steps) - 1)
+		levels[i] = max(1, int(math.Ceil(rate*float64(top))))
+	}
+	return levels
+}()
+
+// displayLevel returns the character and color index an intensity is drawn
+// with under the configured intensity scale
+func (m Model) displayLevel(intensity int) int {
+	if m.prefs.IntensityScale == config.IntensityScaleLog && intensity < len(logIntensityLevels) {
+		return logIntensityLevels[intensity]
+	}
+	return intensity
+}
+
+// boostMinIntensity is the weakest intensity still drawn while contrast
+// boost is on, roughly where heavy rain starts
+const boostMinIntensity = 6
+
+// toggleContrastBoost switches the emphasis on the strongest cells for this
+// session
+func (m Model) toggleContrastBoost() Model {
+	m.contrastBoost = !m.contrastBoost
+	return m
+}
+
+// drawsIntensity reports whether an intensity is drawn at all; contrast
+// boost drops everything below the heavy cores
+func (m Model) drawsIntensity(intensity int) bool {
+	return intensity > 0 && (!m.contrastBoost || intensity >= boostMinIntensity)
+}
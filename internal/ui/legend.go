@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// legendSwatchWidth is how many cells wide each color in the legend is
+const legendSwatchWidth = 3
+
+// approxDBZ estimates the reflectivity an intensity was decoded from. The
+// decoder buckets by color rather than by value, so this is only a guide.
+func approxDBZ(intensity int) int {
+	return int(math.Round(10 + 5.5*float64(intensity)))
+}
+
+// renderLegend draws the precipitation color key, light to heavy, with the
+// approximate dBZ each color starts at underneath. Colors no intensity maps
+// to under the current scale are left out.
+func (m Model) renderLegend() string {
+	// The lowest intensity drawn with each display level
+	first := make(map[int]int)
+	for intensity := len(precipColors) - 1; intensity > 0; intensity-- {
+		if m.drawsIntensity(intensity) {
+			first[m.displayLevel(intensity)] = intensity
+		}
+	}
+
+	label := config.HelpStyle
+	var swatches, values strings.Builder
+	for level := 1; level < len(precipColors); level++ {
+		intensity, ok := first[level]
+		if !ok {
+			continue
+		}
+		swatches.WriteString(lipgloss.NewStyle().Foreground(precipColors[level]).Bold(m.contrastBoost).
+			Render(strings.Repeat("█", legendSwatchWidth)))
+		values.WriteString(fmt.Sprintf("%-*d", legendSwatchWidth, approxDBZ(intensity)))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		label.Render(" Light ")+swatches.String()+label.Render(" Heavy  [L to hide]"),
+		label.Render("       "+values.String()+"dBZ"),
+	)
+}
@@ -0,0 +1,14 @@
+package ui
+
+import "github.com/N-Erickson/termidar/internal/config"
+
+// WithLocale follows the locale in environ, such as an SSH session's
+// forwarded LANG and LC_* variables, rather than this process's when the
+// units are left to the locale
+func (m Model) WithLocale(environ []string) Model {
+	m.localeUnits = config.EnvironUnits(environ)
+	if m.store.LoadPreferences().Units == "" {
+		m.prefs.Units = m.localeUnits
+	}
+	return m
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// Input length limits: a ZIP code, or a place name once anything but a digit
+// is typed
+const (
+	zipCharLimit   = 5
+	placeCharLimit = 40
+)
+
+// isDigits reports whether s is empty or only digits (private helper)
+func isDigits(s string) bool {
+	return strings.Trim(s, "0123456789") == ""
+}
+
+// typingPlace reports whether a key on the input screen is text for the
+// location rather than a shortcut. Letters are part of place names, so only
+// ? keeps its meaning there.
+func typingPlace(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes && msg.String() != "?"
+}
+
+// updateLocationInput passes a key to the location input, widening its limit
+// as soon as the text stops being a ZIP code and narrowing it again if it
+// goes back to one
+func (m Model) updateLocationInput(msg tea.Msg) (Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && !isDigits(string(key.Runes)) {
+		m.zipInput.CharLimit = placeCharLimit
+	}
+
+	var cmd tea.Cmd
+	m.zipInput, cmd = m.zipInput.Update(msg)
+
+	if value := m.zipInput.Value(); isDigits(value) && len(value) <= zipCharLimit {
+		m.zipInput.CharLimit = zipCharLimit
+	} else {
+		m.zipInput.CharLimit = placeCharLimit
+	}
+	return m, cmd
+}
+
+// submitLocation starts loading whatever is in the input: a 5-digit ZIP code,
+// a 3-digit ZIP prefix for a region, or a "City, ST" place. Anything else
+// explains what's expected.
+func (m Model) submitLocation() (Model, tea.Cmd) {
+	value := strings.TrimSpace(m.zipInput.Value())
+	if isDigits(value) {
+		// A partial ZIP code isn't an error yet, just unfinished
+		if !weather.IsZipCode(value) && !weather.IsZipPrefix(value) {
+			return m, nil
+		}
+	} else if _, err := weather.PlaceQuery(value); err != nil {
+		m.statusMsg = err.Error()
+		return m, nil
+	}
+
+	m.zipCode = value
+	return m.startLoad()
+}
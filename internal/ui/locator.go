@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// The locator pulse runs for locatorSteps ticks, roughly a second in total
+const (
+	locatorSteps    = 6
+	locatorInterval = 160 * time.Millisecond
+)
+
+// LocatorTickMsg advances the on-load locator pulse
+type LocatorTickMsg time.Time
+
+// startLocator begins the crosshair pulse around the home marker
+func (m Model) startLocator() (Model, tea.Cmd) {
+	m.locatorStep = locatorSteps
+	return m, m.tickLocator()
+}
+
+func (m Model) tickLocator() tea.Cmd {
+	return tea.Tick(locatorInterval, func(t time.Time) tea.Msg {
+		return LocatorTickMsg(t)
+	})
+}
+
+// drawLocator draws crosshair arms around the searched location that close in
+// on its marker as the pulse runs down, following it when the view is panned
+// or zoomed
+func (m Model) drawLocator(display [][]string) {
+	if m.locatorStep <= 0 || m.private {
+		return
+	}
+
+	centerX, centerY := m.homeCell()
+	style := lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true)
+
+	// Cells are roughly twice as tall as they are wide, so the horizontal
+	// arms reach twice as far to look square
+	radius := 1 + m.locatorStep%3
+	put := func(x, y int, char string) {
+		if y >= 0 && y < len(display) && x >= 0 && x < len(display[y]) {
+			display[y][x] = style.Render(char)
+		}
+	}
+
+	for r := radius; r <= radius+1; r++ {
+		put(centerX-2*r, centerY, "─")
+		put(centerX+2*r, centerY, "─")
+		put(centerX, centerY-r, "│")
+		put(centerX, centerY+r, "│")
+	}
+	put(centerX-2*radius, centerY-radius, "┌")
+	put(centerX+2*radius, centerY-radius, "┐")
+	put(centerX-2*radius, centerY+radius, "└")
+	put(centerX+2*radius, centerY+radius, "┘")
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// pingPongDwell is how many frame intervals a ping-pong loop holds on its
+// oldest and newest frames before turning around
+const pingPongDwell = 3
+
+// interpolationSteps is how many ticks a frame interval is split into while
+// interpolating: the frame itself, then blends ever closer to the next one
+const interpolationSteps = 4
+
+// setFrame shows frame i. Every frame change goes through it so a blend
+// toward whichever frame was next never carries over to the new one.
+func (m *Model) setFrame(i int) {
+	m.currentFrame = i
+	m.subFrame = 0
+}
+
+// advanceFrame moves the loop on one frame. A wrapping loop jumps from the
+// newest frame back to the oldest; a ping-pong loop walks back down instead,
+// turning around at each end.
+func (m *Model) advanceFrame() {
+	n := len(m.radar.Frames)
+	if !m.prefs.PingPong || n < 2 {
+		m.setFrame((m.currentFrame + 1) % n)
+		return
+	}
+
+	if m.playingBackward && m.currentFrame <= 0 {
+		m.playingBackward = false
+	} else if !m.playingBackward && m.currentFrame >= n-1 {
+		m.playingBackward = true
+	}
+	if m.playingBackward {
+		m.setFrame(min(m.currentFrame, n) - 1)
+	} else {
+		m.setFrame(m.currentFrame + 1)
+	}
+}
+
+// stepFrame moves the loop on one tick: to the next blend step while
+// interpolating, or to the next frame once the blends are done
+func (m *Model) stepFrame() {
+	if m.interpolating() {
+		m.subFrame++
+		if m.subFrame < interpolationSteps {
+			return
+		}
+	}
+	m.advanceFrame()
+}
+
+// interpolating reports whether the current frame blends into the next one.
+// A wrapping loop cuts straight from the newest frame back to the oldest
+// rather than sweeping the storm backwards.
+func (m Model) interpolating() bool {
+	n := len(m.radar.Frames)
+	if !m.prefs.Interpolate || n < 2 {
+		return false
+	}
+	return m.prefs.PingPong || m.currentFrame < n-1
+}
+
+// nextFrame is the frame advanceFrame moves to from the current one
+func (m Model) nextFrame() int {
+	m.advanceFrame()
+	return m.currentFrame
+}
+
+// displayedData is the grid the radar draws: the current frame, or while an
+// interpolating loop plays, a blend of it and the next frame
+func (m Model) displayedData() [][]int {
+	frame := m.radar.Frames[m.currentFrame]
+	if m.subFrame == 0 || !m.animationActive || m.isPaused || !m.interpolating() {
+		return frame.Data
+	}
+	next := m.radar.Frames[m.nextFrame()]
+	return radar.BlendFrames(frame, next, float64(m.subFrame)/interpolationSteps)
+}
+
+// frameDelay is how long the current frame stays up before the next tick:
+// the frame rate, held longer at the ends of a ping-pong loop and on the
+// newest frame. While interpolating the frame rate is shared out between the
+// blend steps and any hold stays on the frame itself.
+func (m Model) frameDelay() time.Duration {
+	last := len(m.radar.Frames) - 1
+	delay := m.frameRate
+	if m.prefs.PingPong && last > 0 && (m.currentFrame == 0 || m.currentFrame >= last) {
+		delay = m.frameRate * pingPongDwell
+	}
+	if last > 0 && m.currentFrame >= last {
+		delay = max(delay, m.prefs.HoldLast())
+	}
+	if m.interpolating() {
+		step := m.frameRate / interpolationSteps
+		if m.subFrame > 0 {
+			return step
+		}
+		return delay - m.frameRate + step
+	}
+	return delay
+}
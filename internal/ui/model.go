@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/N-Erickson/termidar/internal/config"
 	"github.com/N-Erickson/termidar/internal/geography"
@@ -25,6 +27,8 @@ const (
 	StateLoading
 	StateDisplaying
 	StateError
+	StateSettings
+	StateFavorites
 )
 
 // Model represents the application state
@@ -38,32 +42,111 @@ type Model struct {
 	width               int
 	height              int
 	errorMsg            string
+	errorFailure        radar.Failure
+	reconnectSeq        int
+	reconnectAt         time.Time
+	reconnectAttempts   int
 	showHelp            bool
 	isPaused            bool
+	playingBackward     bool // a ping-pong loop is on its way back down
+	subFrame            int  // blend step toward the next frame while interpolating
 	frameRate           time.Duration
 	lastRefresh         time.Time
 	autoRefresh         bool
 	zipCode             string
 	animationActive     bool
 	isBackgroundRefresh bool
+	prefs               config.Preferences
+	frameEntry          string
+	frameEntryActive    bool
+	statusMsg           string
+	splitProducts       bool
+	velocityView        bool
+	locatorStep         int
+	replayTime          time.Time
+	replayEntry         string
+	replayEntryActive   bool
+	confirmingQuit      bool
+	refreshInterval     time.Duration
+	refreshSeq          int
+	following           bool
+	stormTrack          []radar.TrackPoint
+	trackLat            float64
+	trackLon            float64
+	viewCenter          *weather.Location
+	alertIndex          int
+	settingsCursor      int
+	settingsPrefs       config.Preferences
+	showHistogram       bool
+	showSources         bool
+	showAlerts          bool
+	alertScroll         int
+	alertTurn           int
+	alertRotating       bool
+	loadStarted         time.Time
+	loadDuration        time.Duration
+	comparing           bool
+	compareFrame        int
+	diffMode            bool
+	private             bool
+	contrastBoost       bool
+	hourlyLoading       bool
+	forecast            []weather.ForecastPeriod
+	forecastLoading     bool
+	loadProgress        radar.ProgressMsg
+	cancelLoad          func()
+	zoomSteps           int
+	geocoder            weather.Geocoder
+	favorites           []config.Favorite
+	favoritesCursor     int
+	noteInput           textinput.Model
+	editingNote         bool
+	recent              []config.HistoryEntry
+	recentCursor        int
+	store               config.Store
+	exportsOff          bool
+	localeUnits         string // what an automatic units setting resolves to
 }
 
 // Messages
 type TickMsg time.Time
 type FrameTickMsg time.Time
-type RefreshTickMsg time.Time
 type ErrorMsg struct {
 	Err error
 }
-type ProgressMsg float64
+type windLoadedMsg struct {
+	Wind []weather.WindVector
+}
+type hourlyLoadedMsg struct {
+	Hourly []weather.HourlyPeriod
+}
 
-// InitialModel creates and returns a new model
+// InitialModel creates and returns a new model using the saved preferences
 func InitialModel() Model {
+	return NewModel(config.LoadPreferences())
+}
+
+// NewModel creates a model configured from the given preferences that
+// remembers locations and settings in the config directory
+func NewModel(prefs config.Preferences) Model {
+	return NewModelWithStore(prefs, config.FileStore{})
+}
+
+// NewModelWithStore creates a model configured from the given preferences
+// that remembers locations and settings in store
+func NewModelWithStore(prefs config.Preferences, store config.Store) Model {
+	// An explicit units setting wins; otherwise follow the locale, which
+	// WithLocale replaces with an SSH client's
+	localeUnits := config.LocaleUnits()
+	if prefs.Units == "" {
+		prefs.Units = localeUnits
+	}
+
 	ti := textinput.New()
-	ti.Placeholder = "Enter ZIP code"
+	ti.Placeholder = "Enter ZIP or city, state"
 	ti.Focus()
-	ti.CharLimit = 5
-	ti.Width = 20
+	ti.CharLimit = zipCharLimit
+	ti.Width = 30
 	ti.Prompt = "📍 "
 
 	s := spinner.New()
@@ -76,21 +159,39 @@ func InitialModel() Model {
 		progress.WithoutPercentage(),
 	)
 
+	width, height := terminalSize()
+
 	return Model{
 		state:           StateInput,
 		zipInput:        ti,
 		spinner:         s,
 		progress:        p,
-		width:           80,
-		height:          40,
-		frameRate:       300 * time.Millisecond,
-		autoRefresh:     true,
+		width:           width,
+		height:          height,
+		frameRate:       prefs.FrameRate(),
+		autoRefresh:     prefs.Refresh.Enabled,
+		refreshInterval: prefs.RefreshMin(),
+		alertIndex:      -1,
 		animationActive: false,
+		prefs:           prefs,
+		favorites:       store.LoadFavorites(),
+		noteInput:       newNoteInput(),
+		recent:          store.LoadHistory(),
+		recentCursor:    -1,
+		store:           store,
+		localeUnits:     localeUnits,
 	}
 }
 
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
+	if m.state == StateLoading {
+		// Resuming the last session
+		return tea.Batch(
+			m.spinner.Tick,
+			radar.LoadData(m.zipCode, m.loadOptions()),
+		)
+	}
 	return textinput.Blink
 }
 
@@ -100,24 +201,86 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.confirmingQuit {
+			return m.updateQuitConfirm(msg)
+		}
+		if m.frameEntryActive {
+			return m.updateFrameEntry(msg)
+		}
+		if m.replayEntryActive {
+			return m.updateReplayEntry(msg)
+		}
+		if m.state == StateSettings {
+			return m.updateSettings(msg)
+		}
+		if m.state == StateFavorites {
+			return m.updateFavorites(msg)
+		}
+		if m.showSources {
+			return m.updateSources(msg)
+		}
+		if m.showAlerts {
+			return m.updateAlertDetails(msg)
+		}
+
+		// Status messages only last until the next key press
+		m.statusMsg = ""
+
+		if m.state == StateInput && m.selectingRecent(msg) {
+			return m.updateRecent(msg)
+		}
+		if m.state == StateInput && typingPlace(msg) {
+			return m.updateLocationInput(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
-			return m, tea.Quit
+			return m.requestQuit()
 		case "esc":
-			if m.state == StateDisplaying || m.state == StateError {
+			if m.state == StateDisplaying || m.state == StateError || m.state == StateLoading {
+				if m.state == StateLoading && m.cancelLoad != nil {
+					// Stop the abandoned load's requests rather than letting
+					// it run on in the background
+					m.cancelLoad()
+				}
 				m.animationActive = false
 				m = m.ResetToInput()
-				return m, textinput.Blink
+				return m, tea.Batch(textinput.Blink, m.updateTitle())
 			}
 		case "enter":
-			if m.state == StateInput && len(m.zipInput.Value()) == 5 {
-				m.state = StateLoading
-				m.zipCode = m.zipInput.Value()
-				cmds = append(cmds,
-					m.spinner.Tick,
-					radar.LoadData(m.zipCode),
-					m.TrackProgress(),
-				)
+			if m.state == StateInput {
+				return m.submitLocation()
+			}
+		case "ctrl+o":
+			if m.state == StateInput {
+				return m.openSettings(), nil
+			}
+		case "ctrl+f":
+			if m.state == StateInput {
+				return m.openFavorites(), nil
+			}
+		case "ctrl+s":
+			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
+				if m.exportsOff {
+					m.statusMsg = exportsOffMsg
+				} else {
+					m.statusMsg = "Saving GIF..."
+					cmds = append(cmds, m.saveGIF())
+				}
+			}
+		case "ctrl+t":
+			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
+				if m.exportsOff {
+					m.statusMsg = exportsOffMsg
+				} else {
+					cmds = append(cmds, m.saveFrameText())
+				}
+			}
+		case "*":
+			if m.state == StateDisplaying {
+				var cmd tea.Cmd
+				m, cmd = m.toggleFavorite()
+				cmds = append(cmds, cmd)
 			}
 		case "?", "h":
 			m.showHelp = !m.showHelp
@@ -128,32 +291,294 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.animationActive = true
 					cmds = append(cmds, m.AnimateFrame())
 				}
+				paused := m.isPaused
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Paused = paused
+				}))
 			}
 		case "r":
 			if m.state == StateDisplaying && m.zipCode != "" {
-				m.animationActive = false
-				m.state = StateLoading
-				cmds = append(cmds,
-					m.spinner.Tick,
-					radar.LoadData(m.zipCode),
-					m.TrackProgress(),
-				)
+				var cmd tea.Cmd
+				m, cmd = m.startLoad()
+				cmds = append(cmds, cmd)
+			}
+		case "t":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				m = m.startReplayEntry()
+			}
+		case "V":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				m.splitProducts = !m.splitProducts
+				if m.splitProducts && len(m.radar.Secondary) == 0 {
+					// Velocity wasn't part of the last load, so fetch both products
+					var cmd tea.Cmd
+					m, cmd = m.startLoad()
+					cmds = append(cmds, cmd)
+				}
+			}
+		case "v":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				var cmd tea.Cmd
+				m, cmd = m.toggleVelocityView()
+				cmds = append(cmds, cmd)
+			}
+		case "w":
+			if m.state == StateDisplaying {
+				enabled := !m.prefs.WindBarbs
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.WindBarbs = enabled
+				}))
+				if enabled && len(m.radar.Wind) == 0 {
+					cmds = append(cmds, fetchWind(m.radar.Lat, m.radar.Lon))
+				}
+			}
+		case "g", "G":
+			if m.state == StateDisplaying {
+				enabled := !m.prefs.HourlyForecast
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.HourlyForecast = enabled
+				}))
+				if enabled && len(m.radar.Hourly) == 0 {
+					m.hourlyLoading = true
+					cmds = append(cmds, fetchHourly(m.radar.HomeLat, m.radar.HomeLon))
+				}
+			}
+		case "W":
+			if m.state == StateDisplaying {
+				enabled := !m.prefs.Forecast
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Forecast = enabled
+				}))
+				if enabled && len(m.forecast) == 0 {
+					cmds = append(cmds, m.loadForecast())
+				}
+			}
+		case "p":
+			if m.state == StateDisplaying {
+				show := !m.prefs.AlertPolygons
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.AlertPolygons = show
+				}))
+			}
+		case "P":
+			if m.state == StateDisplaying {
+				filter := cycle(config.PolygonFilters, m.prefs.AlertPolygonFilter, 1)
+				m.statusMsg = "Warning areas: " + polygonFilterNames[filter]
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.AlertPolygons = true
+					p.AlertPolygonFilter = filter
+				}))
+			}
+		case "C":
+			if m.state == StateDisplaying {
+				m = m.toggleCompare()
+			}
+		case "D":
+			if m.state == StateDisplaying {
+				m.diffMode = !m.diffMode
+			}
+		case "[", "]":
+			if m.state == StateDisplaying && m.comparing {
+				step := 1
+				if msg.String() == "[" {
+					step = -1
+				}
+				m = m.stepCompareFrame(step)
+			}
+		case "tab", "shift+tab":
+			if m.state == StateDisplaying {
+				step := 1
+				if msg.String() == "shift+tab" {
+					step = -1
+				}
+				var cmd tea.Cmd
+				m, cmd = m.cycleAlertArea(step)
+				cmds = append(cmds, cmd)
+			}
+		case "f":
+			if m.state == StateDisplaying && m.replayTime.IsZero() {
+				var cmd tea.Cmd
+				m, cmd = m.toggleFollow()
+				cmds = append(cmds, cmd)
+			}
+		case "F":
+			if m.state == StateDisplaying && m.replayTime.IsZero() {
+				var cmd tea.Cmd
+				m, cmd = m.lockStorm(true)
+				cmds = append(cmds, cmd)
+			}
+		case "i":
+			if m.state == StateDisplaying {
+				m.showHistogram = !m.showHistogram
+			}
+		case "A":
+			if m.state == StateDisplaying {
+				m = m.toggleSources()
+			}
+		case "!":
+			if m.state == StateDisplaying {
+				m = m.toggleAlertDetails()
+			}
+		case ">", ".":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				var cmd tea.Cmd
+				m, cmd = m.zoom(-1)
+				cmds = append(cmds, cmd)
+			}
+		case "<", ",":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				var cmd tea.Cmd
+				m, cmd = m.zoom(1)
+				cmds = append(cmds, cmd)
+			}
+		case "e":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				var cmd tea.Cmd
+				m, cmd = m.regeocode()
+				cmds = append(cmds, cmd)
+			}
+		case "s":
+			if m.state == StateDisplaying && m.zipCode != "" {
+				var cmd tea.Cmd
+				m, cmd = m.cycleRadarSource()
+				cmds = append(cmds, cmd)
+			}
+		case "l", "L":
+			if m.state == StateDisplaying {
+				legend := !m.prefs.Legend
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Legend = legend
+				}))
+			}
+		case "b", "B":
+			if m.state == StateDisplaying {
+				m = m.toggleContrastBoost()
+			}
+		case "x", "X":
+			if m.state == StateDisplaying {
+				m = m.togglePrivate()
+				cmds = append(cmds, m.updateTitle())
+			}
+		case "z", "Z":
+			if m.state == StateDisplaying {
+				var cmd tea.Cmd
+				m, cmd = m.toggleUTC()
+				cmds = append(cmds, cmd)
+			}
+		case "u", "U":
+			if m.state == StateDisplaying {
+				var cmd tea.Cmd
+				m, cmd = m.toggleUnits()
+				cmds = append(cmds, cmd, m.updateTitle())
+			}
+		case "o":
+			if m.state == StateDisplaying {
+				blend := !m.prefs.PrecipBlend
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.PrecipBlend = blend
+				}))
+			}
+		case "O":
+			if m.state == StateDisplaying {
+				mapOnTop := !m.prefs.MapOnTop
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.MapOnTop = mapOnTop
+				}))
+			}
+		case "c":
+			if m.state == StateDisplaying {
+				cities := !m.prefs.Cities
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Cities = cities
+				}))
+			}
+		case "S":
+			if m.state == StateDisplaying {
+				borders := !m.prefs.StateBorders
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.StateBorders = borders
+				}))
+			}
+		case "I":
+			if m.state == StateDisplaying {
+				interstates := !m.prefs.Interstates
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Interstates = interstates
+				}))
+			}
+		case "~":
+			if m.state == StateDisplaying {
+				waterways := !m.prefs.Waterways
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Waterways = waterways
+				}))
+			}
+		case "^":
+			if m.state == StateDisplaying {
+				mountains := !m.prefs.Mountains
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Mountains = mountains
+				}))
+			}
+		case "R":
+			if m.state == StateDisplaying {
+				rings := !m.prefs.DistanceRings
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.DistanceRings = rings
+				}))
+			}
+		case "m", "M":
+			if m.state == StateDisplaying {
+				pingPong := !m.prefs.PingPong
+				m.playingBackward = false
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.PingPong = pingPong
+				}))
+			}
+		case "y", "Y":
+			if m.state == StateDisplaying {
+				interpolate := !m.prefs.Interpolate
+				m.subFrame = 0
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Interpolate = interpolate
+				}))
+			}
+		case "#":
+			if m.state == StateDisplaying {
+				graticule := !m.prefs.Graticule
+				cmds = append(cmds, m.setPreference(func(p *config.Preferences) {
+					p.Graticule = graticule
+				}))
 			}
 		case "left", "a":
 			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
-				m.currentFrame = (m.currentFrame - 1 + len(m.radar.Frames)) % len(m.radar.Frames)
+				m.setFrame((m.currentFrame - 1 + len(m.radar.Frames)) % len(m.radar.Frames))
 			}
 		case "right", "d":
 			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
-				m.currentFrame = (m.currentFrame + 1) % len(m.radar.Frames)
+				m.setFrame((m.currentFrame + 1) % len(m.radar.Frames))
+			}
+		case "n", "N":
+			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
+				step := 1
+				if msg.String() == "N" {
+					step = -1
+				}
+				m = m.jumpToPrecipFrame(step)
+			}
+		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if m.state == StateDisplaying && len(m.radar.Frames) > 0 {
+				m = m.startFrameEntry(msg.String())
 			}
 		case "+", "=":
-			if m.frameRate > 100*time.Millisecond {
+			if m.frameRate > config.MinFrameRate {
 				m.frameRate -= 100 * time.Millisecond
+				cmds = append(cmds, m.saveFrameRate())
 			}
 		case "-", "_":
-			if m.frameRate < 2*time.Second {
+			if m.frameRate < config.MaxFrameRate {
 				m.frameRate += 100 * time.Millisecond
+				cmds = append(cmds, m.saveFrameRate())
 			}
 		}
 
@@ -168,69 +593,194 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
-	case ProgressMsg:
+	case radar.ProgressMsg:
+		// Keep following even a load abandoned with ESC, until its final
+		// message arrives
+		cmds = append(cmds, msg.Next())
 		if m.state == StateLoading {
-			cmd := m.progress.SetPercent(float64(msg))
-			cmds = append(cmds, cmd)
+			m.loadProgress = msg
+			m.cancelLoad = msg.Cancel
+			cmds = append(cmds, m.progress.SetPercent(msg.Fraction))
 		}
 
+	case progress.FrameMsg:
+		updated, cmd := m.progress.Update(msg)
+		m.progress = updated.(progress.Model)
+		cmds = append(cmds, cmd)
+
 	case radar.LoadedMsg:
+		// A load abandoned with ESC has nowhere to go
+		if m.state == StateInput || m.state == StateSettings || m.state == StateFavorites {
+			break
+		}
+
 		// oldRadar := m.radar
 		m.radar = msg.Radar
+		m.reconnectAttempts = 0
+		cmds = append(cmds, m.updateTitle(), m.loadForecast())
+
+		// A new search opens on the most severe alert; refreshes keep cycling
+		if !m.isBackgroundRefresh {
+			m.alertTurn = 0
+		}
+		var alertCmd tea.Cmd
+		m, alertCmd = m.startAlertRotation()
+		cmds = append(cmds, alertCmd)
+		if !m.loadStarted.IsZero() {
+			m.loadDuration = time.Since(m.loadStarted)
+		}
 
 		// If this is a background refresh, preserve the animation state
 		if m.state == StateDisplaying && m.isBackgroundRefresh {
 			m.isBackgroundRefresh = false
 			m.lastRefresh = time.Now()
 			// Don't reset frame or pause state during background refresh
-			// Keep the animation running smoothly
+			// Keep the animation running smoothly, but stay within the new
+			// loop if it came back shorter
+			if m.currentFrame >= len(m.radar.Frames) {
+				m.setFrame(max(0, len(m.radar.Frames)-1))
+			}
 		} else {
 			// Normal load behavior
+			m.isBackgroundRefresh = false
 			m.state = StateDisplaying
-			m.currentFrame = 0
-			m.isPaused = false
 			m.lastRefresh = time.Now()
 
-			if !m.animationActive {
+			if m.prefs.StartMode == config.StartModeLatest && len(m.radar.Frames) > 0 {
+				// Land on the newest frame for inspection instead of playing the loop
+				m.setFrame(len(m.radar.Frames) - 1)
+				m.isPaused = true
+			} else {
+				m.setFrame(0)
+				m.isPaused = m.prefs.Paused
+			}
+
+			if !m.isPaused && !m.animationActive {
 				m.animationActive = true
 				cmds = append(cmds, m.AnimateFrame())
 			}
+
+			// Draw the eye to the user's location before the loop gets busy
+			var cmd tea.Cmd
+			m, cmd = m.startLocator()
+			cmds = append(cmds, cmd, m.recordHistory())
+		}
+
+		if m.following {
+			m = m.updateFollow()
 		}
 
-		if m.autoRefresh && !m.isBackgroundRefresh {
+		// Each completed load schedules the next one, so there is only
+		// ever one refresh pending
+		m.refreshInterval = m.nextRefreshInterval()
+		if m.autoRefresh && m.replayTime.IsZero() {
 			cmds = append(cmds, m.ScheduleRefresh())
 		}
 
 	case RefreshTickMsg:
-		if m.state == StateDisplaying && m.autoRefresh && m.zipCode != "" {
+		// Archived frames don't change, so replays are never refreshed
+		if msg.Seq == m.refreshSeq && m.state == StateDisplaying && m.autoRefresh && m.zipCode != "" && m.replayTime.IsZero() {
 			// Don't show loading state during auto-refresh
 			// Just load the data in the background
-			cmds = append(cmds, radar.LoadData(m.zipCode))
-			cmds = append(cmds, m.ScheduleRefresh())
+			m.isBackgroundRefresh = true
+			m.loadStarted = time.Now()
+			cmds = append(cmds, radar.LoadData(m.zipCode, m.loadOptions()))
 		}
 
 	case FrameTickMsg:
 		if m.state == StateDisplaying && m.animationActive && !m.isPaused && len(m.radar.Frames) > 0 {
-			m.currentFrame = (m.currentFrame + 1) % len(m.radar.Frames)
+			m.stepFrame()
 			cmds = append(cmds, m.AnimateFrame())
 		} else {
 			m.animationActive = false
 		}
 
+	case AlertTickMsg:
+		if m.state == StateDisplaying && len(m.activeAlerts()) > 1 {
+			m.alertTurn++
+			cmds = append(cmds, m.tickAlerts())
+		} else {
+			m.alertRotating = false
+		}
+
+	case LocatorTickMsg:
+		if m.locatorStep > 0 {
+			m.locatorStep--
+			if m.locatorStep > 0 {
+				cmds = append(cmds, m.tickLocator())
+			}
+		}
+
+	case windLoadedMsg:
+		if m.state == StateDisplaying {
+			m.radar.Wind = msg.Wind
+		}
+
+	case forecastLoadedMsg:
+		m.forecastLoading = false
+		if m.state == StateDisplaying && len(msg.Forecast) > 0 {
+			m.forecast = msg.Forecast
+		}
+
+	case hourlyLoadedMsg:
+		m.hourlyLoading = false
+		if m.state == StateDisplaying {
+			m.radar.Hourly = msg.Hourly
+		}
+
 	case radar.ErrorMsg:
+		if m.state == StateInput || m.state == StateSettings || m.state == StateFavorites {
+			break
+		}
+		if m.isBackgroundRefresh && m.state == StateDisplaying {
+			// Keep showing the last good loop and try again later
+			m.isBackgroundRefresh = false
+			headline, _ := m.explainError(radar.ClassifyError(msg.Err), msg.Err.Error())
+			m.statusMsg = "Refresh failed: " + headline
+			if m.autoRefresh {
+				cmds = append(cmds, m.ScheduleRefresh())
+			}
+			break
+		}
 		m.state = StateError
 		m.errorMsg = msg.Err.Error()
+		m.errorFailure = radar.ClassifyError(msg.Err)
 		m.animationActive = false
+		if m.errorFailure == radar.FailureNetwork && m.prefs.ReconnectDelay() > 0 {
+			cmds = append(cmds, m.scheduleReconnect())
+		}
+
+	case reconnectMsg:
+		if msg.Seq == m.reconnectSeq && m.state == StateError && m.zipCode != "" {
+			m.reconnectAttempts++
+			var cmd tea.Cmd
+			m, cmd = m.startLoad()
+			cmds = append(cmds, cmd)
+		}
+
+	case exportedMsg:
+		// A failed export is only reported, so the loop keeps playing
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("Couldn't save the %s: %v", msg.What, msg.Err)
+		} else {
+			m.statusMsg = fmt.Sprintf("Saved the %s to %s", msg.What, msg.Path)
+		}
 
 	case ErrorMsg:
 		m.state = StateError
 		m.errorMsg = msg.Err.Error()
+		m.errorFailure = radar.ClassifyError(msg.Err)
 		m.animationActive = false
 	}
 
 	if m.state == StateInput {
 		var cmd tea.Cmd
-		m.zipInput, cmd = m.zipInput.Update(msg)
+		m, cmd = m.updateLocationInput(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == StateFavorites && m.editingNote {
+		var cmd tea.Cmd
+		m.noteInput, cmd = m.noteInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 
@@ -247,13 +797,29 @@ func (m Model) View() string {
 	case StateInput:
 		inputBox := m.renderInputBox()
 		help := m.renderHelp()
-		content = lipgloss.JoinVertical(lipgloss.Left, header, inputBox, help)
+		content = lipgloss.JoinVertical(lipgloss.Left, header, inputBox)
+		if recent := m.renderRecent(); recent != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content, recent)
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, content, help)
+		if m.statusMsg != "" {
+			content = lipgloss.JoinVertical(lipgloss.Left, content,
+				lipgloss.NewStyle().Foreground(config.AccentColor).Render(m.statusMsg))
+		}
 
 	case StateLoading:
 		loadingView := m.renderLoading()
 		content = lipgloss.JoinVertical(lipgloss.Left, header, loadingView)
 
 	case StateDisplaying:
+		if m.showSources {
+			content = lipgloss.JoinVertical(lipgloss.Left, header, m.renderSources())
+			break
+		}
+		if m.showAlerts {
+			content = lipgloss.JoinVertical(lipgloss.Left, header, m.renderAlertDetails())
+			break
+		}
 		radarView := m.renderRadar()
 		controls := m.renderControls()
 		content = lipgloss.JoinVertical(lipgloss.Left, header, radarView, controls)
@@ -261,9 +827,19 @@ func (m Model) View() string {
 	case StateError:
 		errorView := m.renderError()
 		content = lipgloss.JoinVertical(lipgloss.Left, header, errorView)
+
+	case StateSettings:
+		content = lipgloss.JoinVertical(lipgloss.Left, header, m.renderSettings())
+
+	case StateFavorites:
+		content = lipgloss.JoinVertical(lipgloss.Left, header, m.renderFavorites())
+	}
+
+	if m.confirmingQuit {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, "", m.renderQuitConfirm())
 	}
 
-	return config.AppStyle.Render(content)
+	return m.fitToWindow(config.AppStyle.Render(content))
 }
 
 // Render functions
@@ -273,36 +849,37 @@ func (m Model) renderInputBox() string {
 		style = config.ActiveInputStyle
 	}
 
-	prompt := "Enter a US ZIP code to view weather radar:"
+	prompt := "Enter a US ZIP code or city and state to view weather radar\n(or a ZIP code's first 3 digits for a regional overview):"
 	input := m.zipInput.View()
 
 	box := style.Render(
 		lipgloss.JoinVertical(lipgloss.Left, prompt, "", input),
 	)
 
-	examples := config.SubtitleStyle.Render("Try: 10001 (NYC), 60601 (Chicago), 98101 (Seattle), 33101 (Miami)")
+	examples := config.SubtitleStyle.Render("Try: 10001 (NYC), 60601 (Chicago), 98101 (Seattle),\nor a place like Boulder, CO")
 
 	return lipgloss.JoinVertical(lipgloss.Left, box, examples)
 }
 
+// loadingMessages describe what each load stage is doing
+var loadingMessages = map[radar.Stage]string{
+	radar.StageLocating:   "Locating...",
+	radar.StageStation:    "Finding nearest radar station...",
+	radar.StageConditions: "Checking current conditions and alerts...",
+	radar.StageFrames:     "Fetching radar data...",
+	radar.StageProcessing: "Processing frames...",
+}
+
 func (m Model) renderLoading() string {
 	spinner := m.spinner.View()
 	progress := config.ProgressStyle.Render(m.progress.View())
 
-	messages := []string{
-		"Locating ZIP code...",
-		"Finding nearest radar station...",
-		"Fetching radar data...",
-		"Processing frames...",
+	message := loadingMessages[m.loadProgress.Stage]
+	if m.loadProgress.Stage == radar.StageFrames && m.loadProgress.TotalFrames > 0 {
+		message = fmt.Sprintf("Fetching radar frames (%d/%d)...", m.loadProgress.Frames, m.loadProgress.TotalFrames)
 	}
 
-	progressPercent := m.progress.Percent()
-	messageIdx := int(progressPercent * float64(len(messages)-1))
-	if messageIdx >= len(messages) {
-		messageIdx = len(messages) - 1
-	}
-
-	status := fmt.Sprintf("%s %s", spinner, messages[messageIdx])
+	status := fmt.Sprintf("%s %s", spinner, message)
 
 	return lipgloss.JoinVertical(lipgloss.Center,
 		"",
@@ -319,83 +896,118 @@ func (m Model) renderRadar() string {
 	}
 
 	info := m.renderInfoPanel()
-	radarDisplay := m.renderRadarFrame()
+
+	radarDisplay := ""
+	if m.comparing {
+		radarDisplay = m.renderCompare()
+	} else if m.splitProducts {
+		radarDisplay = m.renderProductSplit()
+	} else if m.velocityView {
+		radarDisplay = m.renderVelocityFrame()
+	} else if m.diffMode {
+		radarDisplay = m.renderDiffFrame()
+	} else {
+		radarDisplay = m.renderRadarFrame()
+	}
+
+	// The diff and velocity views have their own keys
+	if m.prefs.Legend && !m.diffMode && !m.velocityView {
+		radarDisplay = lipgloss.JoinVertical(lipgloss.Left, radarDisplay, m.renderLegend())
+	}
+	if m.prefs.Forecast {
+		radarDisplay = lipgloss.JoinVertical(lipgloss.Left, radarDisplay, m.renderForecast())
+	}
+	if m.prefs.HourlyForecast {
+		radarDisplay = lipgloss.JoinVertical(lipgloss.Left, radarDisplay, m.renderHourly())
+	}
+	if winter := m.renderWinter(); winter != "" {
+		radarDisplay = lipgloss.JoinVertical(lipgloss.Left, radarDisplay, winter)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, info, radarDisplay)
 }
 
 func (m Model) renderInfoPanel() string {
-	location := config.LocationStyle.Render(fmt.Sprintf("📍 %s", m.radar.Location))
+	location := config.LocationStyle.Render(fmt.Sprintf("📍 %s", m.locationName()))
 	station := config.StationStyle.Render(fmt.Sprintf("📡 Station: %s", m.radar.Station))
 
 	// Check for severe weather alerts
 	alertDisplay := ""
-	if len(m.radar.Alerts) > 0 {
-		emoji, color, text := weather.GetAlertDisplay(m.radar.Alerts)
+	if alert, pos, count, ok := m.shownAlert(); ok {
+		// Several alerts take turns, each styled on its own
+		emoji, color, text := weather.GetAlertDisplay([]weather.Alert{alert})
 		if emoji != "" {
 			alertStyle := lipgloss.NewStyle().
 				Foreground(color).
 				Bold(true)
 
-			for _, alert := range m.radar.Alerts {
-				if alert.Severity == "Extreme" {
-					alertStyle = alertStyle.
-						Background(lipgloss.Color("52")).
-						Padding(0, 1)
-					break
-				}
+			if alert.Severity == "Extreme" {
+				alertStyle = alertStyle.
+					Background(lipgloss.Color("52")).
+					Padding(0, 1)
+			}
+
+			// Say how close the warning edge is when the alert has a polygon,
+			// unless that would give away where the viewer is
+			if miles, dir, ok := alert.BoundaryDistance(m.radar.HomeLat, m.radar.HomeLon); ok && !m.private {
+				text = fmt.Sprintf("%s • boundary %s %s", text, m.formatDistance(miles), dir)
+			}
+			if count > 1 {
+				text = fmt.Sprintf("%s (%d/%d)", text, pos+1, count)
 			}
 
 			alertDisplay = alertStyle.Render(fmt.Sprintf("%s %s", emoji, text))
 		}
 	}
 
-	// Temperature display
+	// Temperature display; a nil temperature means the station didn't report one
 	tempDisplay := ""
-	if m.radar.Temperature != 0 {
-		tempDisplay = fmt.Sprintf("%d°F", m.radar.Temperature)
-		tempColor := lipgloss.Color("87")
-		if m.radar.Temperature >= 90 {
-			tempColor = lipgloss.Color("196")
-		} else if m.radar.Temperature >= 70 {
-			tempColor = lipgloss.Color("214")
-		} else if m.radar.Temperature >= 50 {
-			tempColor = lipgloss.Color("226")
-		} else if m.radar.Temperature >= 32 {
-			tempColor = lipgloss.Color("87")
-		} else {
-			tempColor = lipgloss.Color("51")
-		}
-		tempDisplay = lipgloss.NewStyle().Foreground(tempColor).Bold(true).Render(tempDisplay)
+	if t := m.radar.Celsius; t != nil {
+		tempDisplay = lipgloss.NewStyle().Foreground(m.tempColor(*t)).Bold(true).Render(m.formatTemp(*t))
+	} else if m.radar.Conditions != "" {
+		_, unit := m.displayTemp(0)
+		tempDisplay = config.HelpStyle.Render("--" + unit)
 	}
 
 	// Weather condition emoji
 	conditionEmoji := weather.GetEmoji(m.radar.Conditions)
 
-	// Show frame timestamp info
+	// Show frame timestamp info, with live frames' age colored by how
+	// current it is
 	var frameInfo string
 	if len(m.radar.Frames) > 0 && m.currentFrame < len(m.radar.Frames) {
 		frame := m.radar.Frames[m.currentFrame]
-		timeAgo := time.Since(frame.Timestamp).Round(time.Minute)
-		frameInfo = fmt.Sprintf("Frame %d/%d (%s ago)",
-			m.currentFrame+1, len(m.radar.Frames), timeAgo)
+		if m.replayTime.IsZero() {
+			age := time.Since(frame.Timestamp)
+			frameInfo = config.HelpStyle.Render(fmt.Sprintf("Frame %d/%d ", m.currentFrame+1, len(m.radar.Frames)))
+			if m.prefs.UTC {
+				// Name the frame's time so the Z clock has something to show
+				frameInfo += config.HelpStyle.Render(m.formatClock(frame.Timestamp, "15:04") + " ")
+			}
+			frameInfo += ageStyle(age).Render(fmt.Sprintf("(%s ago)", age.Round(time.Minute)))
+		} else {
+			frameInfo = config.HelpStyle.Render(fmt.Sprintf("Replay • Frame %d/%d (%s)",
+				m.currentFrame+1, len(m.radar.Frames), m.formatClock(frame.Timestamp, "Jan 2 15:04")))
+		}
 	} else {
-		frameInfo = fmt.Sprintf("Frame %d/%d", m.currentFrame+1, len(m.radar.Frames))
+		frameInfo = config.HelpStyle.Render(fmt.Sprintf("Frame %d/%d", m.currentFrame+1, len(m.radar.Frames)))
 	}
 
 	if m.isPaused {
-		frameInfo += " (PAUSED)"
+		frameInfo += config.HelpStyle.Render(" (PAUSED)")
 	}
 
-	// Add last refresh time
+	// Add last refresh time, colored the same way
 	refreshInfo := ""
-	if !m.lastRefresh.IsZero() {
+	if m.isStale() {
+		refreshInfo = staleStyle.Render(" • " + m.staleWarning())
+	} else if !m.lastRefresh.IsZero() {
 		timeSinceRefresh := time.Since(m.lastRefresh).Round(time.Second)
+		updated := fmt.Sprintf("Updated %dm ago", int(timeSinceRefresh.Minutes()))
 		if timeSinceRefresh < time.Minute {
-			refreshInfo = fmt.Sprintf(" • Updated %ds ago", int(timeSinceRefresh.Seconds()))
-		} else {
-			refreshInfo = fmt.Sprintf(" • Updated %dm ago", int(timeSinceRefresh.Minutes()))
+			updated = fmt.Sprintf("Updated %ds ago", int(timeSinceRefresh.Seconds()))
 		}
+		refreshInfo = config.HelpStyle.Render(" • ") + ageStyle(timeSinceRefresh).Render(updated)
 	}
 
 	// Build the info panel
@@ -416,7 +1028,37 @@ func (m Model) renderInfoPanel() string {
 		lines = append(lines, alertDisplay)
 	}
 	lines = append(lines, topLine)
-	lines = append(lines, config.HelpStyle.Render(frameInfo+refreshInfo))
+	if details := m.renderObservationDetails(); details != "" {
+		lines = append(lines, details)
+	}
+	if note := m.favoriteNote(); note != "" {
+		lines = append(lines, config.HelpStyle.Render("📝 "+note))
+	}
+	if m.velocityView && !m.splitProducts && !m.comparing {
+		lines = append(lines, lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true).
+			Render("BASE VELOCITY: green toward the radar, red away [v to exit]"))
+	}
+	if m.contrastBoost {
+		lines = append(lines, lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true).
+			Render("CONTRAST BOOST: heavy cores only [B to exit]"))
+	}
+	if m.prefs.RadarSource != config.RadarSourceAuto {
+		lines = append(lines, lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true).
+			Render(fmt.Sprintf("Source: %s only [S to change]", radarSourceName(m.prefs.RadarSource))))
+	}
+	lines = append(lines, frameInfo+refreshInfo)
+	if caption := m.clearCaption(); caption != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(config.SuccessColor).Render(caption))
+	}
+	if m.radar.IsRealData {
+		homeX, homeY := m.homeCell()
+		if trend := radar.DescribeTrend(radar.CenterSeries(m.radar.Frames, homeX, homeY)); trend != "" {
+			lines = append(lines, config.SubtitleStyle.Render("At your location: "+trend))
+		}
+	}
+	if m.following {
+		lines = append(lines, lipgloss.NewStyle().Foreground(config.AccentColor).Render(m.stormMotionSummary()))
+	}
 
 	return config.InfoPanelStyle.Render(
 		lipgloss.JoinVertical(lipgloss.Left, lines...),
@@ -424,8 +1066,55 @@ func (m Model) renderInfoPanel() string {
 }
 
 func (m Model) renderRadarFrame() string {
-	frame := m.radar.Frames[m.currentFrame]
+	return m.renderRadarPanel(m.radarGrid(), "")
+}
+
+// radarGrid draws the current frame and its overlays on the radar grid,
+// without the panel around it
+func (m Model) radarGrid() [][]string {
+	display := m.newDisplay()
 
+	// Draw precipitation data
+	if data := m.displayedData(); data != nil {
+		m.DrawPrecipitation(display, data)
+	}
+
+	m.drawAlertPolygons(display)
+	m.drawStormMarker(display)
+	m.drawLocator(display)
+	m.drawClearOverlay(display)
+	if m.showHistogram {
+		m.drawHistogram(display)
+	}
+
+	return display
+}
+
+// drawAlertPolygons outlines warning areas on top of the radar when enabled
+// and allowed by the polygon filter, highlighting the one selected with Tab
+func (m Model) drawAlertPolygons(display [][]string) {
+	var alerts []weather.Alert
+	highlight := -1
+	for i, alert := range m.radar.Alerts {
+		// The area picked with Tab is always shown
+		if i == m.alertIndex {
+			highlight = len(alerts)
+		} else if !m.prefs.AlertPolygons || !polygonShown(m.prefs.AlertPolygonFilter, alert) {
+			continue
+		}
+		alerts = append(alerts, alert)
+	}
+	geography.DrawAlertPolygons(display, m.viewProjection(), alerts, highlight)
+}
+
+// homeCell returns the display cell of the searched location, which is the
+// center unless the view has panned
+func (m Model) homeCell() (int, int) {
+	return m.viewProjection().ToDisplay(m.radar.HomeLat, m.radar.HomeLon)
+}
+
+// newDisplay creates a blank radar grid with the geographic overlay drawn
+func (m Model) newDisplay() [][]string {
 	// Create the radar display grid
 	display := make([][]string, config.RadarHeight)
 	for i := range display {
@@ -435,31 +1124,64 @@ func (m Model) renderRadarFrame() string {
 		}
 	}
 
-	// Get center coordinates from the radar station
-	centerX, centerY := config.RadarWidth/2, config.RadarHeight/2
-
 	// Draw geographic boundaries FIRST (so radar data appears on top)
-	geography.DrawGeographicBoundaries(display, centerX, centerY, m.zipCode)
+	geography.DrawGeographicBoundaries(display, m.viewProjection(), geography.Layers{
+		StateBorders: m.prefs.StateBorders,
+		Interstates:  m.prefs.Interstates,
+		Water:        m.prefs.Waterways,
+		Mountains:    m.prefs.Mountains,
+	})
 
-	// Draw simple distance markers
-	geography.DrawDistanceMarkers(display, centerX, centerY)
+	// City names would give the location away in private mode
+	if m.prefs.Cities && !m.private {
+		geography.DrawCities(display, m.viewProjection())
+	}
 
-	// Draw precipitation data
-	if frame.Data != nil {
-		m.DrawPrecipitation(display, frame.Data)
+	if !m.private {
+		homeX, homeY := m.homeCell()
+		geography.DrawLocationMarker(display, homeX, homeY)
+	}
+
+	// Draw the distance rings
+	if m.prefs.DistanceRings {
+		geography.DrawDistanceMarkers(display, m.viewProjection())
+	}
+
+	// Wind sits beneath precipitation, so it only fills cells left empty
+	if m.prefs.WindBarbs {
+		geography.DrawWindBarbs(display, m.viewProjection(), m.radar.Wind)
 	}
+	if m.prefs.Graticule {
+		geography.DrawGraticule(display, m.viewProjection())
+	}
+
+	return display
+}
 
-	// Add scale indicator
-	scaleInfo := "───── = 50 miles"
+// renderRadarPanel wraps a drawn grid with the frame indicator, scale, and an
+// optional legend line inside the radar container
+func (m Model) renderRadarPanel(display [][]string, legend string) string {
+	// Add scale indicator, which follows the zoom and grows with a
+	// zoomed-out region
+	scale := m.radar.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	scaleInfo := fmt.Sprintf("───── = %g miles", 50*scale)
+	if m.metric() {
+		scaleInfo = fmt.Sprintf("───── = %g km", 80*scale)
+	}
 
-	// Add frame indicator dots at bottom
+	// Add frame indicator dots at bottom, shaded so newer frames are brighter
 	var frameIndicator strings.Builder
-	for i := 0; i < len(m.radar.Frames); i++ {
+	for i, frame := range m.radar.Frames {
+		dot := "·"
 		if i == m.currentFrame {
-			frameIndicator.WriteString("●")
-		} else {
-			frameIndicator.WriteString("·")
+			dot = "●"
 		}
+		frameIndicator.WriteString(lipgloss.NewStyle().
+			Foreground(frameAgeShade(time.Since(frame.Timestamp))).
+			Render(dot))
 		if i < len(m.radar.Frames)-1 {
 			frameIndicator.WriteString(" ")
 		}
@@ -482,13 +1204,44 @@ func (m Model) renderRadarFrame() string {
 		Width(config.RadarWidth).
 		Align(lipgloss.Center).
 		Render(scaleInfo)
+	if legend != "" {
+		radarStr += "\n" + lipgloss.NewStyle().
+			Width(config.RadarWidth).
+			Align(lipgloss.Center).
+			Render(legend)
+	}
 
 	return config.RadarContainerStyle.Render(radarStr)
 }
 
-func (m Model) DrawPrecipitation(display [][]string, data [][]int) {
-	chars := []string{" ", "·", "∘", "○", "●", "◉", "◆", "◈", "▰", "▱", "█"}
-	colors := []lipgloss.Color{
+// frameAgeShade returns a gray that dims as a frame gets older
+func frameAgeShade(age time.Duration) lipgloss.Color {
+	switch {
+	case age <= 10*time.Minute:
+		return lipgloss.Color("255")
+	case age <= 30*time.Minute:
+		return lipgloss.Color("250")
+	case age <= time.Hour:
+		return lipgloss.Color("246")
+	case age <= 2*time.Hour:
+		return lipgloss.Color("242")
+	default:
+		return lipgloss.Color("238")
+	}
+}
+
+// blendMaxIntensity is the intensity at which precipitation stops blending
+// with the geography beneath it and draws solid
+const blendMaxIntensity = 5
+
+// mapOverMaxIntensity is the intensity from which precipitation still covers
+// the map when the map is drawn on top, so heavy and severe cores stay whole
+const mapOverMaxIntensity = 7
+
+// The precipitation ramp, indexed by intensity
+var (
+	precipChars  = []string{" ", "·", "∘", "○", "●", "◉", "◆", "◈", "▰", "▱", "█"}
+	precipColors = []lipgloss.Color{
 		lipgloss.Color("0"),
 		lipgloss.Color("51"),
 		lipgloss.Color("50"),
@@ -501,25 +1254,92 @@ func (m Model) DrawPrecipitation(display [][]string, data [][]int) {
 		lipgloss.Color("196"),
 		lipgloss.Color("160"),
 	}
+)
+
+func (m Model) DrawPrecipitation(display [][]string, data [][]int) {
+	chars := precipChars
+	colors := precipColors
 
 	for y := 0; y < len(data) && y < config.RadarHeight; y++ {
 		for x := 0; x < len(data[y]) && x < config.RadarWidth; x++ {
 			intensity := data[y][x]
-			if intensity > 0 && intensity < len(chars) {
-				char := chars[intensity]
-				color := colors[intensity]
-				display[y][x] = lipgloss.NewStyle().Foreground(color).Render(char)
+			if m.drawsIntensity(intensity) && intensity < len(chars) {
+				level := m.displayLevel(intensity)
+				char := chars[level]
+				color := colors[level]
+
+				// With the map on top, borders and labels win over all but
+				// the heaviest cells
+				if m.prefs.MapOnTop && intensity < mapOverMaxIntensity && display[y][x] != " " {
+					continue
+				}
+
+				// Light precipitation tints whatever geography is beneath it
+				// instead of hiding it
+				if m.prefs.PrecipBlend && intensity < blendMaxIntensity {
+					if under := ansi.Strip(display[y][x]); under != " " {
+						char = under
+					}
+				}
+
+				display[y][x] = lipgloss.NewStyle().Foreground(color).Bold(m.contrastBoost).Faint(m.isStale()).Render(char)
 			}
 		}
 	}
 }
 
 func (m Model) renderControls() string {
+	if m.frameEntryActive {
+		return config.HelpStyle.Render(m.renderFrameEntry())
+	}
+	if m.replayEntryActive {
+		return config.HelpStyle.Render(m.renderReplayEntry())
+	}
+
 	controls := []string{
 		"[Space] Play/Pause",
 		"[←/→] Previous/Next",
+		"[0-9] Jump to frame",
+		"[N] Next with rain",
 		"[R] Refresh",
+		"[T] Replay a past time",
 		"[+/-] Speed",
+		"[M] Ping-pong loop",
+		"[Y] Smooth loop",
+		"[</>] Zoom",
+		"[V] Velocity",
+		"[Shift+V] Velocity split",
+		"[Shift+C] Compare two times",
+		"[Shift+D] Changes",
+		"[W] Wind",
+		"[G] Next 12 hours",
+		"[Shift+W] Forecast",
+		"[O] Blend light rain",
+		"[Shift+O] Map over rain",
+		"[C] Cities",
+		"[Shift+S] Borders",
+		"[Shift+I] Interstates",
+		"[~] Rivers",
+		"[^] Mountains",
+		"[Shift+R] Rings",
+		"[#] Lat/lon grid",
+		"[I] Intensity histogram",
+		"[L] Color key",
+		"[B] Boost contrast",
+		"[Shift+A] Data sources",
+		"[Ctrl+S] Save GIF",
+		"[Ctrl+T] Save as text",
+		"[X] Private",
+		"[U] °F/°C",
+		"[Z] UTC",
+		"[*] Favorite",
+		"[S] Radar source",
+		"[E] Re-geocode",
+		"[P] Warning areas",
+		"[Shift+P] Which areas",
+		"[F] Follow storm",
+		"[Tab] Alert areas",
+		"[!] Alert details",
 		"[ESC] New location",
 		"[Q] Quit",
 	}
@@ -527,37 +1347,89 @@ func (m Model) renderControls() string {
 	if m.showHelp {
 		controls = append(controls, "",
 			fmt.Sprintf("Frame rate: %s", m.frameRate),
-			fmt.Sprintf("Auto-refresh: Every 5 minutes"),
+			fmt.Sprintf("Hold newest frame: %s", m.prefs.HoldLast()),
+			fmt.Sprintf("Auto-refresh: Every %s", m.refreshInterval),
 		)
 	}
 
-	controlStr := config.HelpStyle.Render(strings.Join(controls, " • "))
+	// Wrap between controls to fit inside the app padding
+	controlStr := config.HelpStyle.Render(strings.Join(wrapItems(controls, " • ", max(20, m.width-4)), "\n"))
+	if m.statusMsg != "" {
+		controlStr = lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Foreground(config.AccentColor).Render(m.statusMsg),
+			controlStr,
+		)
+	}
 	return controlStr
 }
 
 func (m Model) renderError() string {
-	errorMsg := config.ErrorStyle.Render("❌ " + m.errorMsg)
+	headline, guidance := m.explainError(m.errorFailure, m.errorMsg)
+	errorMsg := config.ErrorStyle.Render("❌ " + headline)
 	help := config.HelpStyle.Render("Press ESC to try again or Q to quit")
 
-	return lipgloss.JoinVertical(lipgloss.Center,
-		"",
-		errorMsg,
-		"",
-		help,
-	)
+	lines := []string{"", errorMsg}
+	if guidance != "" {
+		lines = append(lines, "", lipgloss.NewStyle().Foreground(config.AccentColor).Render(guidance))
+	}
+	lines = append(lines, "", help)
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
 }
 
 func (m Model) renderHelp() string {
 	help := []string{
 		"🎮 Controls:",
-		"  Enter - Submit ZIP code",
+		"  Enter - Submit ZIP code, city, state or 3-digit ZIP prefix (major cities' only)",
+		"  Ctrl+O - Settings",
+		"  Ctrl+F - Favorites, with notes",
+		"  ↓     - Pick a recent location (1-5 or Enter)",
 		"  ESC   - Cancel/Back",
-		"  Q     - Quit",
+		"  Ctrl+C - Quit (letters type into the location)",
 		"",
 		"📡 During radar display:",
 		"  Space - Play/Pause animation",
+		"  M     - Play the loop forward then back instead of jumping to the start",
+		"  Y     - Smooth the loop with blended steps between frames (uses more CPU)",
 		"  ←/→   - Navigate frames",
+		"  0-9   - Jump to a frame number",
+		"  N     - Next frame with precipitation; Shift+N the previous one",
+		"  T     - Replay radar around a past date and time",
+		"  >/<   - Zoom in/out",
+		"  V     - Show base velocity instead of reflectivity",
+		"  Shift+V - Reflectivity/velocity side by side",
+		"  Shift+C - Compare two frames side by side; [/] pick the left, ←/→ the right",
+		"  Shift+D - Show where rain grew or weakened since the previous frame",
+		"  W     - Toggle forecast wind overlay",
+		"  G     - Show the next 12 hours' temperature and chance of rain",
+		"  Shift+W - Show the forecast for the next few days and nights",
+		"  O     - Let light rain show the map beneath",
+		"  Shift+O - Draw the map over all but heavy rain",
+		"  C     - Show or hide city names",
+		"  Shift+S - Show or hide state borders",
+		"  Shift+I - Show or hide the Interstate Highways",
+		"  ~     - Show or hide rivers, coastlines and the Great Lakes",
+		"  ^     - Show or hide mountain ranges",
+		"  Shift+R - Show or hide the distance rings",
+		"  #     - Show latitude and longitude lines",
+		"  I     - Show how much of the frame is light, moderate, heavy or severe",
+		"  L     - Show or hide the precipitation color key",
+		"  B     - Boost contrast: draw only the heaviest cells, bold",
+		"  Shift+A - Data sources and attribution for what's on screen",
+		"  Ctrl+S - Save the loop as an animated GIF in the current directory",
+		"  Ctrl+T - Save the frame on screen as text, with or without colors",
+		"  X     - Hide your location and marker for screenshots and streams",
+		"  *     - Save or remove this location as a favorite",
+		"  U     - Switch between °F/miles and °C/kilometers",
+		"  Z     - Show times in UTC (Zulu) or local time",
+		"  S     - Fetch from only RainViewer, Iowa State, NWS or simulated data",
+		"  E     - Locate again with another geocoding provider",
+		"  P     - Outline active warning areas",
+		"  Shift+P - Outline all, warnings only, severe only, or tornado warnings",
+		"  F     - Follow the nearest storm; Shift+F locks onto another",
+		"  Tab   - Center on each active alert's area in turn",
+		"  !     - Read every active alert in full, with its time left",
 		"  +/-   - Adjust speed",
+		"  Q     - Quit",
 	}
 
 	if m.showHelp {
@@ -571,32 +1443,97 @@ func (m Model) renderHelp() string {
 func (m Model) ResetToInput() Model {
 	m.state = StateInput
 	m.radar = radar.Data{}
-	m.currentFrame = 0
+	m.setFrame(0)
 	m.errorMsg = ""
+	m.reconnectAttempts = 0
 	m.zipInput.SetValue("")
+	m.zipInput.CharLimit = zipCharLimit
 	m.zipInput.Focus()
+	m.recent = m.store.LoadHistory()
+	m.recentCursor = -1
 	m.animationActive = false
+	m.isBackgroundRefresh = false
+	m.replayTime = time.Time{}
+	m.following = false
+	m.stormTrack = nil
+	m.viewCenter = nil
+	m.alertIndex = -1
+	m.showSources = false
+	m.showAlerts = false
+	m.comparing = false
+	m.cancelLoad = nil
+	m.geocoder = nil
+	m.forecast = nil
 	return m
 }
 
+// startLoad switches to the loading screen and fetches radar for the current
+// ZIP code and view options
+func (m Model) startLoad() (Model, tea.Cmd) {
+	m.animationActive = false
+	m.isBackgroundRefresh = false
+	m.state = StateLoading
+	m.loadStarted = time.Now()
+	m.loadProgress = radar.ProgressMsg{}
+	m.cancelLoad = nil
+	return m, tea.Batch(
+		m.spinner.Tick,
+		m.progress.SetPercent(0),
+		radar.LoadData(m.zipCode, m.loadOptions()),
+	)
+}
+
+// loadOptions builds the radar fetch options for the current view
+func (m Model) loadOptions() radar.Options {
+	opts := radar.Options{}
+	if m.splitProducts || m.velocityView {
+		opts.SecondaryProduct = radar.ProductVelocity
+	}
+	opts.Wind = m.prefs.WindBarbs
+	opts.Hourly = m.prefs.HourlyForecast
+	opts.ObservationStations = m.prefs.ObservationStations()
+	opts.ReplayTime = m.replayTime
+	opts.FrameInterval = m.prefs.FrameInterval()
+	opts.Center = m.viewCenter
+	opts.Source = m.prefs.CustomSource
+	opts.ForceSource = m.prefs.RadarSource
+	opts.Timeout = m.prefs.LoadTimeout()
+	opts.RainViewerRetries = m.prefs.RainViewerRetries
+	opts.Zoom = m.zoomFactor()
+	opts.Geocoder = m.geocoder
+	opts.StationOverlay = m.prefs.StationOverlay
+	return opts
+}
+
+// fetchWind loads the wind grid on its own when the overlay is switched on
+func fetchWind(lat, lon float64) tea.Cmd {
+	return func() tea.Msg {
+		return windLoadedMsg{Wind: weather.FetchWindGrid(context.Background(), lat, lon)}
+	}
+}
+
 // Animation commands
 func (m Model) AnimateFrame() tea.Cmd {
-	return tea.Tick(m.frameRate, func(t time.Time) tea.Msg {
+	return tea.Tick(m.frameDelay(), func(t time.Time) tea.Msg {
 		return FrameTickMsg(t)
 	})
 }
 
-func (m Model) ScheduleRefresh() tea.Cmd {
-	return tea.Tick(5*time.Minute, func(t time.Time) tea.Msg {
-		return RefreshTickMsg(t)
-	})
-}
-
-func (m Model) TrackProgress() tea.Cmd {
+// setPreference applies a preference change to the model and persists it in
+// the background; failures are ignored so a read-only home directory never
+// interrupts the session
+func (m *Model) setPreference(change func(*config.Preferences)) tea.Cmd {
+	change(&m.prefs)
+	store := m.store
 	return func() tea.Msg {
-		for i := 0; i <= 100; i += 10 {
-			time.Sleep(200 * time.Millisecond)
-		}
+		_ = store.UpdatePreferences(change)
 		return nil
 	}
-}
\ No newline at end of file
+}
+
+func (m *Model) saveFrameRate() tea.Cmd {
+	rate := int(m.frameRate / time.Millisecond)
+	return m.setPreference(func(p *config.Preferences) {
+		p.FrameRateMS = rate
+	})
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+)
+
+// hiddenLocation stands in for the location name in private mode
+const hiddenLocation = "Location hidden"
+
+// WithPrivate starts the model with the searched location hidden, for
+// screenshots and streams
+func (m Model) WithPrivate(private bool) Model {
+	return m.setPrivate(private)
+}
+
+// togglePrivate hides or reveals the searched location
+func (m Model) togglePrivate() Model {
+	m = m.setPrivate(!m.private)
+	if m.private {
+		m.statusMsg = "Private mode: location hidden [X to show]"
+	} else {
+		m.statusMsg = "Private mode off"
+	}
+	return m
+}
+
+// setPrivate switches private mode, masking what is typed into the location
+// input while it's on (private helper)
+func (m Model) setPrivate(private bool) Model {
+	m.private = private
+	if private {
+		m.zipInput.EchoMode = textinput.EchoPassword
+		m.zipInput.EchoCharacter = '•'
+	} else {
+		m.zipInput.EchoMode = textinput.EchoNormal
+	}
+	return m
+}
+
+// locationName returns the name of the searched location, unless private
+// mode hides it
+func (m Model) locationName() string {
+	if m.private {
+		return hiddenLocation
+	}
+	return m.radar.Location
+}
+
+// redactQuery removes the searched ZIP code or place from text shown in
+// private mode, such as a geocoding error that repeats it
+func (m Model) redactQuery(text string) string {
+	if !m.private || m.zipCode == "" {
+		return text
+	}
+	return strings.ReplaceAll(text, m.zipCode, "•••")
+}
@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// Velocity shades from weakest to strongest, inbound (green) and outbound (red)
+var (
+	inboundColors = []lipgloss.Color{
+		lipgloss.Color("22"), lipgloss.Color("28"), lipgloss.Color("34"),
+		lipgloss.Color("40"), lipgloss.Color("46"),
+	}
+	outboundColors = []lipgloss.Color{
+		lipgloss.Color("52"), lipgloss.Color("88"), lipgloss.Color("124"),
+		lipgloss.Color("160"), lipgloss.Color("196"),
+	}
+)
+
+// renderProductSplit draws reflectivity and velocity for the current frame
+// side by side, each with its own legend
+func (m Model) renderProductSplit() string {
+	frame := m.radar.Frames[m.currentFrame]
+
+	left := m.newDisplay()
+	if frame.Data != nil {
+		m.DrawPrecipitation(left, frame.Data)
+	}
+
+	right := m.newDisplay()
+	velocityLegend := m.renderVelocityLegend()
+	if m.currentFrame < len(m.radar.Secondary) && m.radar.Secondary[m.currentFrame].Data != nil {
+		m.DrawVelocity(right, m.radar.Secondary[m.currentFrame].Data)
+	} else {
+		velocityLegend = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+			Render(fmt.Sprintf("%s unavailable for %s", radar.ProductName(radar.ProductVelocity), m.radar.Station))
+	}
+
+	m.drawAlertPolygons(left)
+	m.drawAlertPolygons(right)
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderRadarPanel(left, m.renderReflectivityLegend()),
+		m.renderRadarPanel(right, velocityLegend),
+	)
+}
+
+// renderVelocityFrame draws base velocity alone in place of reflectivity,
+// for reading storm motion and rotation at full size
+func (m Model) renderVelocityFrame() string {
+	return m.renderRadarPanel(m.velocityGrid())
+}
+
+// velocityGrid draws the current velocity frame and its overlays on the
+// radar grid, returning it with the key to show under it
+func (m Model) velocityGrid() ([][]string, string) {
+	display := m.newDisplay()
+	legend := m.renderVelocityLegend()
+	if frame, ok := m.velocityFrame(); ok {
+		m.DrawVelocity(display, frame.Data)
+	} else {
+		legend = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).
+			Render(fmt.Sprintf("%s unavailable for %s", radar.ProductName(radar.ProductVelocity), m.radar.Station))
+	}
+
+	m.drawAlertPolygons(display)
+	m.drawLocator(display)
+	return display, legend
+}
+
+// velocityFrame returns the velocity frame fetched for the current time
+func (m Model) velocityFrame() (radar.Frame, bool) {
+	if m.currentFrame >= len(m.radar.Secondary) || m.radar.Secondary[m.currentFrame].Data == nil {
+		return radar.Frame{}, false
+	}
+	return m.radar.Secondary[m.currentFrame], true
+}
+
+// toggleVelocityView switches the radar between reflectivity and base
+// velocity, reloading if velocity wasn't part of the last load
+func (m Model) toggleVelocityView() (Model, tea.Cmd) {
+	m.velocityView = !m.velocityView
+	if m.velocityView && len(m.radar.Secondary) == 0 {
+		return m.startLoad()
+	}
+	return m, nil
+}
+
+// DrawVelocity draws a signed velocity grid: green inbound, red outbound
+func (m Model) DrawVelocity(display [][]string, data [][]int) {
+	for y := 0; y < len(data) && y < len(display); y++ {
+		for x := 0; x < len(data[y]) && x < len(display[y]); x++ {
+			v := data[y][x]
+			if v == 0 {
+				continue
+			}
+
+			palette := outboundColors
+			magnitude := v
+			if v < 0 {
+				palette = inboundColors
+				magnitude = -v
+			}
+
+			shade := (magnitude - 1) * len(palette) / 10
+			if shade >= len(palette) {
+				shade = len(palette) - 1
+			}
+
+			char := "░"
+			if magnitude >= 7 {
+				char = "▓"
+			} else if magnitude >= 4 {
+				char = "▒"
+			}
+
+			display[y][x] = lipgloss.NewStyle().Foreground(palette[shade]).Render(char)
+		}
+	}
+}
+
+// renderReflectivityLegend renders a one-line light-to-heavy key
+func (m Model) renderReflectivityLegend() string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	return label.Render(radar.ProductName(radar.ProductReflectivity)+": light ") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("51")).Render("·") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("226")).Render("●") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Render("◈") +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("160")).Render("█") +
+		label.Render(" heavy")
+}
+
+// renderVelocityLegend renders a one-line inbound/outbound key
+func (m Model) renderVelocityLegend() string {
+	label := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	return label.Render(radar.ProductName(radar.ProductVelocity)+": ") +
+		lipgloss.NewStyle().Foreground(inboundColors[len(inboundColors)-1]).Render("▓▒░ inbound") +
+		label.Render(" • ") +
+		lipgloss.NewStyle().Foreground(outboundColors[len(outboundColors)-1]).Render("outbound ░▒▓")
+}
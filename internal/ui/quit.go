@@ -0,0 +1,32 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// requestQuit quits immediately, or asks first when confirm_quit is set
+func (m Model) requestQuit() (tea.Model, tea.Cmd) {
+	if !m.prefs.ConfirmQuit {
+		return m, tea.Quit
+	}
+	m.confirmingQuit = true
+	return m, nil
+}
+
+// updateQuitConfirm handles the key press answering the quit prompt; anything
+// other than y or a second quit key keeps the session going
+func (m Model) updateQuitConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.confirmingQuit = false
+	switch msg.String() {
+	case "y", "Y", "q", "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderQuitConfirm renders the quit prompt shown beneath the current screen
+func (m Model) renderQuitConfirm() string {
+	return config.ErrorStyle.Render("Quit termidar? [y/N]")
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// maxRecent is how many recent locations are offered under the input
+const maxRecent = 5
+
+// recentLocations returns the history entries offered as suggestions. They
+// are hidden in private mode, since they give away where you've looked.
+func (m Model) recentLocations() []config.HistoryEntry {
+	if m.private {
+		return nil
+	}
+	return m.recent[:min(len(m.recent), maxRecent)]
+}
+
+// selectingRecent reports whether a key on the input screen belongs to the
+// recent locations list: either it's already selected, or ↓ moves into it
+func (m Model) selectingRecent(msg tea.KeyMsg) bool {
+	if m.recentCursor >= 0 {
+		return true
+	}
+	return msg.String() == "down" && len(m.recentLocations()) > 0
+}
+
+// updateRecent handles key presses while the recent locations list is
+// selected. Number keys and Enter load an entry; ↑ past the first one, or
+// anything else typed, goes back to the input.
+func (m Model) updateRecent(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	recent := m.recentLocations()
+	if len(recent) == 0 {
+		return m.focusInput(msg)
+	}
+
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m.requestQuit()
+	case "down":
+		m.recentCursor = min(m.recentCursor+1, len(recent)-1)
+		m.zipInput.Blur()
+	case "up":
+		m.recentCursor--
+		if m.recentCursor < 0 {
+			m.zipInput.Focus()
+		}
+	case "esc":
+		return m.focusInput(nil)
+	case "enter":
+		return m.loadRecent(recent[m.recentCursor])
+	default:
+		if n, err := strconv.Atoi(key); err == nil && n >= 1 && n <= len(recent) {
+			return m.loadRecent(recent[n-1])
+		}
+		return m.focusInput(msg)
+	}
+	return m, nil
+}
+
+// focusInput leaves the recent locations list, passing on the key that did
+// so (if any) to the input
+func (m Model) focusInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.recentCursor = -1
+	m.zipInput.Focus()
+	if msg == nil {
+		return m, nil
+	}
+	return m.Update(msg)
+}
+
+// loadRecent loads a location picked from the recent list
+func (m Model) loadRecent(entry config.HistoryEntry) (tea.Model, tea.Cmd) {
+	m.recentCursor = -1
+	m.zipCode = entry.Query
+	return m.startLoad()
+}
+
+// renderRecent lists the recent locations under the input, numbered for
+// picking
+func (m Model) renderRecent() string {
+	recent := m.recentLocations()
+	if len(recent) == 0 {
+		return ""
+	}
+
+	selected := lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true)
+	rows := []string{"Recent (↓ to pick):"}
+	for i, entry := range recent {
+		row := fmt.Sprintf("  %d. %s  %s", i+1, entry.Query, entry.Location)
+		if i == m.recentCursor {
+			row = selected.Render("▸" + row[1:])
+		}
+		rows = append(rows, row)
+	}
+	return config.HelpStyle.Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reconnectMsg fires when a load that couldn't reach a service is due to be
+// tried again. Seq identifies the failure it came from.
+type reconnectMsg struct {
+	Seq int
+}
+
+// scheduleReconnect starts the countdown to loading again after a network
+// failure, superseding any retry already pending
+func (m *Model) scheduleReconnect() tea.Cmd {
+	m.reconnectSeq++
+	seq := m.reconnectSeq
+	delay := m.prefs.ReconnectDelay()
+	m.reconnectAt = time.Now().Add(delay)
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return reconnectMsg{Seq: seq}
+	})
+}
+
+// reconnectNotice says when a load that couldn't reach a service will be
+// tried again, or to try later when retries are off
+func (m Model) reconnectNotice() string {
+	notice := "The weather services are having connectivity issues"
+	if m.prefs.ReconnectDelay() == 0 {
+		return notice + " - try again in a few minutes"
+	}
+	notice += fmt.Sprintf(", retrying at %s…", m.formatClock(m.reconnectAt, "3:04:05 PM"))
+	if m.reconnectAttempts > 0 {
+		notice += fmt.Sprintf(" (attempt %d)", m.reconnectAttempts+1)
+	}
+	return notice
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// RefreshTickMsg fires when a scheduled refresh is due. Seq identifies the
+// schedule it came from so superseded ticks can be dropped.
+type RefreshTickMsg struct {
+	Time time.Time
+	Seq  int
+}
+
+// prefetchMargin is started on top of the last load's duration, so the new
+// frames usually land just before the current ones are due to be replaced
+const prefetchMargin = 5 * time.Second
+
+// ScheduleRefresh starts a new refresh countdown at the current interval,
+// superseding any tick already pending. The background load is kicked off
+// early by about as long as the last one took, so new frames swap in on
+// schedule rather than one load-time late.
+func (m *Model) ScheduleRefresh() tea.Cmd {
+	m.refreshSeq++
+	seq := m.refreshSeq
+	return tea.Tick(m.refreshInterval-m.prefetchLead(), func(t time.Time) tea.Msg {
+		return RefreshTickMsg{Time: t, Seq: seq}
+	})
+}
+
+// prefetchLead is how far ahead of the refresh interval to start loading,
+// never more than half the interval so refreshes don't run back to back
+func (m Model) prefetchLead() time.Duration {
+	if m.loadDuration <= 0 {
+		return 0
+	}
+	return min(m.loadDuration+prefetchMargin, m.refreshInterval/2)
+}
+
+// nextRefreshInterval backs the refresh interval off while the radar is
+// quiet and drops straight back to the minimum once anything shows up
+func (m Model) nextRefreshInterval() time.Duration {
+	minInterval, maxInterval := m.prefs.RefreshBounds()
+	if radarActive(m.radar, m.prefs.Refresh.ActivityFrames) {
+		return minInterval
+	}
+
+	next := m.refreshInterval * 2
+	if next < minInterval {
+		next = minInterval
+	}
+	if next > maxInterval {
+		next = maxInterval
+	}
+	return next
+}
+
+// radarActive reports whether there are alerts or any precipitation in the
+// newest window frames (private helper)
+func radarActive(data radar.Data, window int) bool {
+	return len(data.Alerts) > 0 || radar.FrameActivityWindow(data.Frames, window) > 0
+}
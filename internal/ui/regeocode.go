@@ -0,0 +1,32 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// regeocode reloads the location with the geocoders other than the one that
+// placed it, re-centering on the second opinion, for a ZIP code or place
+// that landed in the wrong spot. Pressed again it moves on to the next
+// provider. The choice lasts until a new location is entered.
+func (m Model) regeocode() (Model, tea.Cmd) {
+	if weather.IsZipPrefix(m.zipCode) {
+		m.statusMsg = "ZIP prefix regions aren't geocoded"
+		return m, nil
+	}
+
+	current := m.radar.Geocoder
+	m.geocoder = weather.AlternateGeocoder(current)
+	m.viewCenter = nil
+	m.following = false
+	m.stormTrack = nil
+	m.alertIndex = -1
+
+	m, cmd := m.startLoad()
+	m.statusMsg = "Locating again with another provider"
+	if current != "" {
+		m.statusMsg += " than " + current
+	}
+	return m, cmd
+}
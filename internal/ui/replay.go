@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// startReplayEntry opens the replay prompt, seeded with the current replay time
+func (m Model) startReplayEntry() Model {
+	m.replayEntryActive = true
+	m.replayEntry = ""
+	if !m.replayTime.IsZero() {
+		m.replayEntry = m.replayTime.In(m.clockZone()).Format(radar.ReplayTimeLayout)
+	}
+	return m
+}
+
+// updateReplayEntry handles key presses while the replay prompt is open
+func (m Model) updateReplayEntry(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m.requestQuit()
+	case tea.KeyEsc:
+		m.replayEntryActive = false
+		m.replayEntry = ""
+	case tea.KeyBackspace:
+		if len(m.replayEntry) > 0 {
+			m.replayEntry = m.replayEntry[:len(m.replayEntry)-1]
+		}
+	case tea.KeyEnter:
+		return m.applyReplayEntry()
+	case tea.KeySpace:
+		if len(m.replayEntry) < len(radar.ReplayTimeLayout) {
+			m.replayEntry += " "
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if (r >= '0' && r <= '9' || r == '-' || r == ':') && len(m.replayEntry) < len(radar.ReplayTimeLayout) {
+				m.replayEntry += string(r)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// applyReplayEntry parses the prompt and reloads for that time. An empty
+// prompt returns to live radar.
+func (m Model) applyReplayEntry() (tea.Model, tea.Cmd) {
+	entry := strings.TrimSpace(m.replayEntry)
+	m.replayEntryActive = false
+	m.replayEntry = ""
+
+	var target time.Time
+	if entry != "" {
+		t, err := radar.ParseReplayTimeIn(entry, m.clockZone())
+		if err != nil {
+			m.statusMsg = err.Error()
+			return m, nil
+		}
+		target = t
+	}
+
+	if target.Equal(m.replayTime) {
+		return m, nil
+	}
+
+	m.replayTime = target
+	return m.startLoad()
+}
+
+// WithReplayTime starts the model in replay mode for the first location
+// entered, as set by the --time flag
+func (m Model) WithReplayTime(t time.Time) Model {
+	m.replayTime = t
+	return m
+}
+
+// renderReplayEntry renders the replay prompt shown in place of the controls
+func (m Model) renderReplayEntry() string {
+	zone := "local"
+	if m.prefs.UTC {
+		zone = "UTC"
+	}
+	return fmt.Sprintf("Replay time: %s_  (YYYY-MM-DD HH:MM %s, empty for live, Enter to load, Esc to cancel)",
+		m.replayEntry, zone)
+}
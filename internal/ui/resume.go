@@ -0,0 +1,38 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// WithResume starts the model loading the given location straight away,
+// skipping the ZIP input; ESC while loading still returns to it
+func (m Model) WithResume(query string) Model {
+	if query == "" {
+		return m
+	}
+	m.zipCode = query
+	m.state = StateLoading
+	m.zipInput.Blur()
+	return m
+}
+
+// LastLocation returns the most recently viewed location, if any
+func LastLocation() string {
+	history := config.LoadHistory()
+	if len(history) == 0 {
+		return ""
+	}
+	return history[0].Query
+}
+
+// recordHistory remembers the location just loaded in the background;
+// failures are ignored like preference saves
+func (m Model) recordHistory() tea.Cmd {
+	store, query, location := m.store, m.zipCode, m.radar.Location
+	return func() tea.Msg {
+		_ = store.RecordHistory(query, location)
+		return nil
+	}
+}
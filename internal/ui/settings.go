@@ -0,0 +1,395 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// settingsItem is one row of the settings screen. change steps the value
+// forwards (+1) or backwards (-1).
+type settingsItem struct {
+	label  string
+	value  func(p config.Preferences) string
+	change func(p *config.Preferences, dir int)
+	// detail, when set, adds what the value means for this session in
+	// parentheses, if anything
+	detail func(m Model) string
+}
+
+// onOff renders a boolean setting
+func onOff(v bool) string {
+	if v {
+		return "on"
+	}
+	return "off"
+}
+
+// cycle steps through a fixed list of choices, wrapping at either end
+func cycle(choices []string, current string, dir int) string {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[(i+dir+len(choices))%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+// settingsItems lists the settings screen rows in display order
+var settingsItems = []settingsItem{
+	{
+		label: "Units",
+		value: func(p config.Preferences) string {
+			if p.Units == "" {
+				return "auto"
+			}
+			return p.Units
+		},
+		detail: func(m Model) string {
+			if m.settingsPrefs.Units == "" {
+				return m.localeUnits
+			}
+			return ""
+		},
+		change: func(p *config.Preferences, dir int) {
+			p.Units = cycle([]string{"", config.UnitsImperial, config.UnitsMetric}, p.Units, dir)
+		},
+	},
+	{
+		label: "Clock",
+		value: func(p config.Preferences) string {
+			if p.UTC {
+				return "UTC"
+			}
+			return "local"
+		},
+		change: func(p *config.Preferences, dir int) { p.UTC = !p.UTC },
+	},
+	{
+		label: "Frame rate",
+		value: func(p config.Preferences) string { return p.FrameRate().String() },
+		change: func(p *config.Preferences, dir int) {
+			// Right makes the loop faster, matching + in the radar view
+			rate := p.FrameRate() - time.Duration(dir)*100*time.Millisecond
+			rate = max(config.MinFrameRate, min(config.MaxFrameRate, rate))
+			p.FrameRateMS = int(rate / time.Millisecond)
+		},
+	},
+	{
+		label: "Start mode",
+		value: func(p config.Preferences) string { return p.StartMode },
+		change: func(p *config.Preferences, dir int) {
+			p.StartMode = cycle([]string{config.StartModeLoop, config.StartModeLatest}, p.StartMode, dir)
+		},
+	},
+	{
+		label: "Hold newest frame",
+		value: func(p config.Preferences) string { return p.HoldLast().String() },
+		change: func(p *config.Preferences, dir int) {
+			hold := p.HoldLast() + time.Duration(dir)*500*time.Millisecond
+			p.HoldLastMS = int(max(0, min(config.MaxHoldLast, hold)) / time.Millisecond)
+		},
+	},
+	{
+		label:  "Ping-pong loop",
+		value:  func(p config.Preferences) string { return onOff(p.PingPong) },
+		change: func(p *config.Preferences, dir int) { p.PingPong = !p.PingPong },
+	},
+	{
+		label:  "Smooth loop",
+		value:  func(p config.Preferences) string { return onOff(p.Interpolate) },
+		change: func(p *config.Preferences, dir int) { p.Interpolate = !p.Interpolate },
+	},
+	{
+		label: "Frame spacing",
+		value: func(p config.Preferences) string { return p.FrameInterval().String() },
+		change: func(p *config.Preferences, dir int) {
+			current := int(p.FrameInterval() / time.Minute)
+			for i, minutes := range config.FrameIntervals {
+				if minutes == current {
+					n := len(config.FrameIntervals)
+					p.FrameIntervalMin = config.FrameIntervals[(i+dir+n)%n]
+					return
+				}
+			}
+		},
+	},
+	{
+		label:  "Wind overlay",
+		value:  func(p config.Preferences) string { return onOff(p.WindBarbs) },
+		change: func(p *config.Preferences, dir int) { p.WindBarbs = !p.WindBarbs },
+	},
+	{
+		label:  "Hourly outlook",
+		value:  func(p config.Preferences) string { return onOff(p.HourlyForecast) },
+		change: func(p *config.Preferences, dir int) { p.HourlyForecast = !p.HourlyForecast },
+	},
+	{
+		label:  "Forecast strip",
+		value:  func(p config.Preferences) string { return onOff(p.Forecast) },
+		change: func(p *config.Preferences, dir int) { p.Forecast = !p.Forecast },
+	},
+	{
+		label:  "Warning areas",
+		value:  func(p config.Preferences) string { return onOff(p.AlertPolygons) },
+		change: func(p *config.Preferences, dir int) { p.AlertPolygons = !p.AlertPolygons },
+	},
+	{
+		label: "Warning area filter",
+		value: func(p config.Preferences) string { return polygonFilterNames[p.AlertPolygonFilter] },
+		change: func(p *config.Preferences, dir int) {
+			p.AlertPolygonFilter = cycle(config.PolygonFilters, p.AlertPolygonFilter, dir)
+		},
+	},
+	{
+		label:  "Blend light rain",
+		value:  func(p config.Preferences) string { return onOff(p.PrecipBlend) },
+		change: func(p *config.Preferences, dir int) { p.PrecipBlend = !p.PrecipBlend },
+	},
+	{
+		label:  "Map over rain",
+		value:  func(p config.Preferences) string { return onOff(p.MapOnTop) },
+		change: func(p *config.Preferences, dir int) { p.MapOnTop = !p.MapOnTop },
+	},
+	{
+		label:  "Cities",
+		value:  func(p config.Preferences) string { return onOff(p.Cities) },
+		change: func(p *config.Preferences, dir int) { p.Cities = !p.Cities },
+	},
+	{
+		label:  "State borders",
+		value:  func(p config.Preferences) string { return onOff(p.StateBorders) },
+		change: func(p *config.Preferences, dir int) { p.StateBorders = !p.StateBorders },
+	},
+	{
+		label:  "Interstates",
+		value:  func(p config.Preferences) string { return onOff(p.Interstates) },
+		change: func(p *config.Preferences, dir int) { p.Interstates = !p.Interstates },
+	},
+	{
+		label:  "Rivers and coasts",
+		value:  func(p config.Preferences) string { return onOff(p.Waterways) },
+		change: func(p *config.Preferences, dir int) { p.Waterways = !p.Waterways },
+	},
+	{
+		label:  "Mountains",
+		value:  func(p config.Preferences) string { return onOff(p.Mountains) },
+		change: func(p *config.Preferences, dir int) { p.Mountains = !p.Mountains },
+	},
+	{
+		label:  "Distance rings",
+		value:  func(p config.Preferences) string { return onOff(p.DistanceRings) },
+		change: func(p *config.Preferences, dir int) { p.DistanceRings = !p.DistanceRings },
+	},
+	{
+		label:  "Lat/lon grid",
+		value:  func(p config.Preferences) string { return onOff(p.Graticule) },
+		change: func(p *config.Preferences, dir int) { p.Graticule = !p.Graticule },
+	},
+	{
+		label:  "Auto-refresh",
+		value:  func(p config.Preferences) string { return onOff(p.Refresh.Enabled) },
+		change: func(p *config.Preferences, dir int) { p.Refresh.Enabled = !p.Refresh.Enabled },
+	},
+	{
+		label: "Refresh every (active)",
+		value: func(p config.Preferences) string {
+			minInterval, _ := p.RefreshBounds()
+			return minInterval.String()
+		},
+		change: func(p *config.Preferences, dir int) {
+			minInterval, maxInterval := p.RefreshBounds()
+			minutes := int(minInterval/time.Minute) + dir
+			p.Refresh.MinMinutes = max(1, min(int(maxInterval/time.Minute), minutes))
+		},
+	},
+	{
+		label: "Refresh every (quiet)",
+		value: func(p config.Preferences) string {
+			_, maxInterval := p.RefreshBounds()
+			return maxInterval.String()
+		},
+		change: func(p *config.Preferences, dir int) {
+			minInterval, maxInterval := p.RefreshBounds()
+			minutes := int(maxInterval/time.Minute) + 5*dir
+			p.Refresh.MaxMinutes = max(int(minInterval/time.Minute), min(120, minutes))
+		},
+	},
+	{
+		label: "Stale after",
+		value: func(p config.Preferences) string {
+			if p.StaleAfter() == 0 {
+				return "never"
+			}
+			return p.StaleAfter().String()
+		},
+		change: func(p *config.Preferences, dir int) {
+			minutes := max(0, p.StaleAfterMinutes) + 15*dir
+			p.StaleAfterMinutes = max(0, min(240, minutes))
+		},
+	},
+	{
+		label: "Retry after outage",
+		value: func(p config.Preferences) string {
+			if p.ReconnectDelay() == 0 {
+				return "never"
+			}
+			return p.ReconnectDelay().String()
+		},
+		change: func(p *config.Preferences, dir int) {
+			seconds := max(0, p.ReconnectSeconds) + 15*dir
+			p.ReconnectSeconds = max(0, min(300, seconds))
+		},
+	},
+	{
+		label:  "Color key",
+		value:  func(p config.Preferences) string { return onOff(p.Legend) },
+		change: func(p *config.Preferences, dir int) { p.Legend = !p.Legend },
+	},
+	{
+		label: "Intensity scale",
+		value: func(p config.Preferences) string { return p.IntensityScale },
+		change: func(p *config.Preferences, dir int) {
+			p.IntensityScale = cycle([]string{config.IntensityScaleLinear, config.IntensityScaleLog}, p.IntensityScale, dir)
+		},
+	},
+	{
+		label: "Clear frame notice",
+		value: func(p config.Preferences) string { return p.ClearNotice },
+		change: func(p *config.Preferences, dir int) {
+			p.ClearNotice = cycle(config.ClearNotices, p.ClearNotice, dir)
+		},
+	},
+	{
+		label: "Small terminal",
+		value: func(p config.Preferences) string { return p.SmallTerminal },
+		change: func(p *config.Preferences, dir int) {
+			p.SmallTerminal = cycle([]string{config.SmallTerminalWarn, config.SmallTerminalClip}, p.SmallTerminal, dir)
+		},
+	},
+	{
+		label: "Radar source",
+		value: func(p config.Preferences) string { return radarSourceName(p.RadarSource) },
+		change: func(p *config.Preferences, dir int) {
+			p.RadarSource = cycle(config.RadarSources, p.RadarSource, dir)
+		},
+	},
+	{
+		label:  "Local radar overlay",
+		value:  func(p config.Preferences) string { return onOff(p.StationOverlay) },
+		change: func(p *config.Preferences, dir int) { p.StationOverlay = !p.StationOverlay },
+	},
+	{
+		label: "Observation station",
+		value: func(p config.Preferences) string { return p.ObservationStation },
+		change: func(p *config.Preferences, dir int) {
+			p.ObservationStation = cycle([]string{config.StationFirstReporting, config.StationNearest}, p.ObservationStation, dir)
+		},
+	},
+	{
+		label:  "Terminal title",
+		value:  func(p config.Preferences) string { return onOff(p.TerminalTitle) },
+		change: func(p *config.Preferences, dir int) { p.TerminalTitle = !p.TerminalTitle },
+	},
+	{
+		label:  "Text export colors",
+		value:  func(p config.Preferences) string { return onOff(p.TextExportANSI) },
+		change: func(p *config.Preferences, dir int) { p.TextExportANSI = !p.TextExportANSI },
+	},
+	{
+		label:  "Resume last location",
+		value:  func(p config.Preferences) string { return onOff(p.ResumeLast) },
+		change: func(p *config.Preferences, dir int) { p.ResumeLast = !p.ResumeLast },
+	},
+	{
+		label:  "Confirm quit",
+		value:  func(p config.Preferences) string { return onOff(p.ConfirmQuit) },
+		change: func(p *config.Preferences, dir int) { p.ConfirmQuit = !p.ConfirmQuit },
+	},
+}
+
+// openSettings switches to the settings screen, editing the saved
+// preferences rather than this session's command-line overrides
+func (m Model) openSettings() Model {
+	m.state = StateSettings
+	m.settingsCursor = 0
+	m.settingsPrefs = m.store.LoadPreferences()
+	m.zipInput.Blur()
+	return m
+}
+
+// updateSettings handles key presses on the settings screen
+func (m Model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.requestQuit()
+	case "esc", "q":
+		return m.closeSettings()
+	case "up", "k":
+		m.settingsCursor = (m.settingsCursor - 1 + len(settingsItems)) % len(settingsItems)
+	case "down", "j":
+		m.settingsCursor = (m.settingsCursor + 1) % len(settingsItems)
+	case "left", "h":
+		m = m.changeSetting(-1)
+	case "right", "l", "enter", " ":
+		m = m.changeSetting(1)
+	}
+	return m, nil
+}
+
+// changeSetting edits the selected row. The session then runs with exactly
+// what is on screen, replacing any command-line overrides.
+func (m Model) changeSetting(dir int) Model {
+	settingsItems[m.settingsCursor].change(&m.settingsPrefs, dir)
+
+	m.prefs = m.settingsPrefs
+	if m.prefs.Units == "" {
+		m.prefs.Units = m.localeUnits
+	}
+	m.frameRate = m.prefs.FrameRate()
+	m.autoRefresh = m.prefs.Refresh.Enabled
+	return m
+}
+
+// closeSettings saves the edited preferences and returns to the input screen
+func (m Model) closeSettings() (tea.Model, tea.Cmd) {
+	m.state = StateInput
+	m.zipInput.Focus()
+	if err := m.store.SavePreferences(m.settingsPrefs); err != nil {
+		m.statusMsg = fmt.Sprintf("Settings not saved: %v", err)
+	}
+	return m, nil
+}
+
+// renderSettings renders the settings screen
+func (m Model) renderSettings() string {
+	labelWidth := 0
+	for _, item := range settingsItems {
+		labelWidth = max(labelWidth, len(item.label))
+	}
+
+	selected := lipgloss.NewStyle().Foreground(config.AccentColor).Bold(true)
+	var rows []string
+	for i, item := range settingsItems {
+		value := item.value(m.settingsPrefs)
+		if item.detail != nil {
+			if detail := item.detail(m); detail != "" {
+				value += " (" + detail + ")"
+			}
+		}
+		row := fmt.Sprintf("  %-*s  %s", labelWidth, item.label, value)
+		if i == m.settingsCursor {
+			row = selected.Render("▸" + row[1:])
+		}
+		rows = append(rows, row)
+	}
+
+	box := config.ActiveInputStyle.Render(strings.Join(rows, "\n"))
+	help := config.HelpStyle.Render("↑/↓ Select • ←/→ Change • ESC Save and return")
+	return lipgloss.JoinVertical(lipgloss.Left, "⚙️  Settings", box, help)
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// Size assumed when neither the terminal nor the environment reports one
+const (
+	defaultWidth  = 80
+	defaultHeight = 40
+)
+
+// terminalSize returns the starting window size for layouts that can't wait
+// for a WindowSizeMsg, which some SSH clients and pipes never send. It asks
+// the terminal on stdout, then COLUMNS and LINES, then assumes 80x40.
+func terminalSize() (int, int) {
+	if w, h, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 && h > 0 {
+		return w, h
+	}
+
+	width, height := defaultWidth, defaultHeight
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		width = cols
+	}
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		height = lines
+	}
+	return width, height
+}
+
+// WithSize sets the window size up front, for hosts such as an SSH server
+// that know the session's PTY size before the program starts. Later
+// WindowSizeMsgs still override it.
+func (m Model) WithSize(width, height int) Model {
+	if width > 0 && height > 0 {
+		m.width = width
+		m.height = height
+	}
+	return m
+}
+
+// fitToWindow returns the rendered screen, or a prompt to widen the window
+// when it's too narrow and small_terminal is warn. Lines that wrap scramble
+// the radar grid, while a screen that is too tall only loses its top rows, so
+// only the width is checked. The layout is measured as drawn, so the
+// side-by-side views ask for more room than the single radar. Nothing else
+// changes, so the loop keeps animating and the full view returns as soon as
+// the window is wide enough.
+func (m Model) fitToWindow(screen string) string {
+	if m.prefs.SmallTerminal == config.SmallTerminalClip {
+		return screen
+	}
+
+	needWidth := lipgloss.Width(screen)
+	if needWidth <= m.width {
+		return screen
+	}
+
+	prompt := lipgloss.JoinVertical(lipgloss.Left,
+		config.ErrorStyle.Render("Terminal too narrow"),
+		config.HelpStyle.Render(fmt.Sprintf("This view needs %d columns; the window has %d.", needWidth, m.width)),
+		config.HelpStyle.Render("Enlarge the window, or press Q to quit."),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, prompt)
+}
+
+// wrapItems joins items with sep into lines no wider than width, breaking
+// only between items. An empty item starts a new line.
+func wrapItems(items []string, sep string, width int) []string {
+	var lines []string
+	line := ""
+	for _, item := range items {
+		switch {
+		case item == "":
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+		case line == "":
+			line = item
+		case lipgloss.Width(line+sep+item) <= width:
+			line += sep + item
+		default:
+			lines = append(lines, line)
+			line = item
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// sourceAttributions credits each data source by the name it's recorded under
+var sourceAttributions = map[string]string{
+	radar.SourceRainViewer: "Radar tiles © RainViewer (rainviewer.com)",
+	radar.SourceIowaState:  "NEXRAD imagery courtesy of the Iowa Environmental Mesonet, Iowa State University",
+	radar.SourceNWS:        "MRMS reflectivity mosaic from NOAA/NWS (opengeo.ncep.noaa.gov), public domain",
+	radar.SourceSimulated:  "Generated locally because no radar source responded - not real weather",
+	"zippopotam.us":        "ZIP lookup by Zippopotam.us",
+	"geocod.io":            "ZIP lookup by Geocodio (geocod.io)",
+	"offline table":        "Built-in table of major-city ZIP codes",
+}
+
+// radarSourceNames labels the config.RadarSources choices
+var radarSourceNames = map[string]string{
+	config.RadarSourceAuto:       "auto",
+	config.RadarSourceRainViewer: radar.SourceRainViewer,
+	config.RadarSourceIowaState:  radar.SourceIowaState,
+	config.RadarSourceNWS:        radar.SourceNWS,
+	config.RadarSourceSimulated:  radar.SourceSimulated,
+}
+
+// radarSourceName labels a radar source choice
+func radarSourceName(source string) string {
+	if name, ok := radarSourceNames[source]; ok {
+		return name
+	}
+	return source
+}
+
+// cycleRadarSource forces the next radar source for this session, without
+// saving it, and reloads from only that source
+func (m Model) cycleRadarSource() (Model, tea.Cmd) {
+	m.prefs.RadarSource = cycle(config.RadarSources, m.prefs.RadarSource, 1)
+	m, cmd := m.startLoad()
+	m.statusMsg = "Radar source: " + radarSourceName(m.prefs.RadarSource)
+	return m, cmd
+}
+
+// toggleSources opens or closes the data sources screen
+func (m Model) toggleSources() Model {
+	m.showSources = !m.showSources
+	return m
+}
+
+// updateSources handles a key press on the data sources screen; quitting
+// still works, anything else returns to the radar
+func (m Model) updateSources(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m.requestQuit()
+	}
+	m.showSources = false
+	return m, nil
+}
+
+// renderSources lists where the loaded data actually came from, with each
+// provider's attribution
+func (m Model) renderSources() string {
+	heading := lipgloss.NewStyle().Bold(true).Foreground(config.PrimaryColor)
+	detail := config.HelpStyle
+
+	var lines []string
+	section := func(title, source, note string) {
+		lines = append(lines, heading.Render(title)+"  "+source)
+		if note != "" {
+			lines = append(lines, detail.Render("  "+note))
+		}
+		lines = append(lines, "")
+	}
+
+	// Frames can come from more than one source when a loop is stitched
+	// together, so count each
+	var order []string
+	counts := map[string]int{}
+	for _, frame := range m.radar.Frames {
+		if counts[frame.Source] == 0 {
+			order = append(order, frame.Source)
+		}
+		counts[frame.Source]++
+	}
+	for _, source := range order {
+		name := source
+		if name == "" {
+			name = "unknown"
+		}
+		section("Radar",
+			fmt.Sprintf("%s - %d of %d frames", name, counts[source], len(m.radar.Frames)),
+			attribution(source, "Custom source set with custom_source in the config file"))
+	}
+	for _, frame := range m.radar.Frames {
+		if frame.Overlay != "" {
+			section("Local radar", radar.SourceIowaState+" RIDGE, "+frame.Overlay+" within its range", attribution(radar.SourceIowaState, ""))
+			break
+		}
+	}
+	if len(m.radar.Secondary) > 0 {
+		section("Velocity", m.radar.Secondary[0].Source+" RIDGE", attribution(m.radar.Secondary[0].Source, ""))
+	}
+
+	geocoder := m.radar.Geocoder
+	if geocoder == "" {
+		geocoder = "cached lookup"
+	}
+	section("ZIP code", geocoder, attribution(m.radar.Geocoder, ""))
+
+	nws := []string{"radar station " + m.radar.Station}
+	if m.radar.ObservationStation != "" && !m.private {
+		nws = append(nws, "conditions from "+m.radar.ObservationStation)
+	}
+	nws = append(nws, "alerts")
+	if len(m.radar.Wind) > 0 {
+		nws = append(nws, "wind forecast")
+	}
+	if len(m.radar.Hourly) > 0 {
+		nws = append(nws, "hourly forecast")
+	}
+	if len(m.forecast) > 0 {
+		nws = append(nws, "forecast")
+	}
+	section("Weather", "National Weather Service - "+strings.Join(nws, ", "),
+		"Public domain data from api.weather.gov")
+
+	section("Map", "Built-in outlines", "Simplified boundaries for orientation, not survey accuracy")
+
+	lines = append(lines, detail.Render("Press any key to return to the radar"))
+
+	return config.InfoPanelStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		append([]string{heading.Render("📚 Data sources & attribution"), ""}, lines...)...))
+}
+
+// attribution returns the credit line for a source, or fallback for one
+// termidar doesn't know
+func attribution(source, fallback string) string {
+	if credit, ok := sourceAttributions[source]; ok {
+		return credit
+	}
+	return fallback
+}
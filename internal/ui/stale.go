@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// staleStyle flags data older than the staleness threshold
+var staleStyle = lipgloss.NewStyle().Foreground(config.ErrorColor).Bold(true)
+
+// isStale reports whether the last successful load is older than the
+// configured threshold, so the radar on screen shouldn't be trusted. Replays
+// are archived data that is never refreshed, so they are never stale.
+func (m Model) isStale() bool {
+	threshold := m.prefs.StaleAfter()
+	if threshold == 0 || m.lastRefresh.IsZero() || !m.replayTime.IsZero() {
+		return false
+	}
+	return time.Since(m.lastRefresh) > threshold
+}
+
+// staleWarning describes how old the data on screen is
+func (m Model) staleWarning() string {
+	age := time.Since(m.lastRefresh).Round(time.Minute)
+	return fmt.Sprintf("⚠ STALE: updated %s ago [R to refresh]", age)
+}
+
+// Data up to freshAge old reads as current and anything past agingAge as old
+const (
+	freshAge = 10 * time.Minute
+	agingAge = 30 * time.Minute
+)
+
+// ageStyle colors how old a frame or the last update is: green while it's
+// current, yellow as it ages and red once it's old
+func ageStyle(age time.Duration) lipgloss.Style {
+	switch {
+	case age < freshAge:
+		return lipgloss.NewStyle().Foreground(config.SuccessColor)
+	case age <= agingAge:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
+	default:
+		return lipgloss.NewStyle().Foreground(config.ErrorColor)
+	}
+}
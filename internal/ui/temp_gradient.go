@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// tempStop is one point on the temperature gradient
+type tempStop struct {
+	celsius float64
+	r, g, b float64
+}
+
+// tempGradient runs from cold blue to hot red through the colors of the
+// fixed bands, so both look alike at the band centers. Readings beyond either
+// end take the end color.
+var tempGradient = []tempStop{
+	{-18, 0x5f, 0x87, 0xff},
+	{-7, 0x00, 0xff, 0xff},
+	{4, 0x5f, 0xff, 0xff},
+	{16, 0xff, 0xff, 0x00},
+	{27, 0xff, 0xaf, 0x00},
+	{35, 0xff, 0x00, 0x00},
+}
+
+// tempColor picks the color for a Celsius reading: a smooth gradient where
+// the terminal has 256 colors or more, which lipgloss narrows to the nearest
+// 256-color entry when truecolor isn't available, and the fixed bands
+// otherwise
+func (m Model) tempColor(celsius float64) lipgloss.Color {
+	if lipgloss.ColorProfile() > termenv.ANSI256 {
+		return m.tempBandColor(celsius)
+	}
+	return gradientColor(celsius)
+}
+
+// gradientColor interpolates the gradient at a Celsius reading
+func gradientColor(celsius float64) lipgloss.Color {
+	first, last := tempGradient[0], tempGradient[len(tempGradient)-1]
+	if celsius <= first.celsius {
+		return stopColor(first)
+	}
+	if celsius >= last.celsius {
+		return stopColor(last)
+	}
+
+	for i := 1; i < len(tempGradient); i++ {
+		low, high := tempGradient[i-1], tempGradient[i]
+		if celsius > high.celsius {
+			continue
+		}
+		t := (celsius - low.celsius) / (high.celsius - low.celsius)
+		return stopColor(tempStop{
+			r: low.r + (high.r-low.r)*t,
+			g: low.g + (high.g-low.g)*t,
+			b: low.b + (high.b-low.b)*t,
+		})
+	}
+	return stopColor(last)
+}
+
+// stopColor renders a gradient color as a hex lipgloss color
+func stopColor(s tempStop) lipgloss.Color {
+	return lipgloss.Color(fmt.Sprintf("#%02x%02x%02x",
+		int(math.Round(s.r)), int(math.Round(s.g)), int(math.Round(s.b))))
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// titlePrefix starts every terminal title the app sets
+const titlePrefix = "termidar"
+
+// titleSupported is false for terminals that can't set a title, such as a
+// dumb terminal or the Linux console, so they never get the escape sequence
+var titleSupported = func() bool {
+	term := os.Getenv("TERM")
+	return term != "" && term != "dumb" && term != "linux"
+}()
+
+// windowTitle returns the short status for the terminal title: the city,
+// its temperature and the most severe alert, just the app name before
+// anything has loaded
+func (m Model) windowTitle() string {
+	var parts []string
+	// Private mode keeps the location out of the tab bar too
+	if city, _, _ := strings.Cut(m.radar.Location, ","); city != "" && !m.private {
+		parts = append(parts, city)
+	}
+	if m.radar.Celsius != nil {
+		parts = append(parts, m.formatTemp(*m.radar.Celsius))
+	}
+	if len(m.radar.Alerts) > 0 {
+		parts = append(parts, "⚠"+weather.MostSevereAlert(m.radar.Alerts).Event)
+	}
+	if len(parts) == 0 {
+		return titlePrefix
+	}
+	return titlePrefix + ": " + strings.Join(parts, " ")
+}
+
+// updateTitle sets the terminal title to the current status, or does
+// nothing when the title is turned off or unsupported
+func (m Model) updateTitle() tea.Cmd {
+	if !m.prefs.TerminalTitle || !titleSupported {
+		return nil
+	}
+	return tea.SetWindowTitle(m.windowTitle())
+}
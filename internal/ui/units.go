@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+)
+
+// kmPerMile converts distances for metric display
+const kmPerMile = 1.609344
+
+// mmPerInch converts snow depths for imperial display
+const mmPerInch = 25.4
+
+// metric reports whether the display should use metric units
+func (m Model) metric() bool {
+	return m.prefs.Units == config.UnitsMetric
+}
+
+// Temperature color bands, from hot down to freezing, in each unit system;
+// anything colder gets the coldest color
+var (
+	tempThresholdsF = []float64{90, 70, 50, 32}
+	tempThresholdsC = []float64{32, 21, 10, 0}
+	tempColors      = []lipgloss.Color{"196", "214", "226", "87"}
+)
+
+// tempCold colors temperatures below freezing
+const tempCold = lipgloss.Color("51")
+
+// displayTemp converts a Celsius reading to whole degrees in the display
+// units, returning the unit symbol with it
+func (m Model) displayTemp(celsius float64) (int, string) {
+	if m.metric() {
+		return int(math.Round(celsius)), "°C"
+	}
+	return int(math.Round(celsius*9/5 + 32)), "°F"
+}
+
+// formatTemp renders a Celsius reading in the display units
+func (m Model) formatTemp(celsius float64) string {
+	degrees, unit := m.displayTemp(celsius)
+	return fmt.Sprintf("%d%s", degrees, unit)
+}
+
+// tempBandColor picks the color for a Celsius reading, comparing the
+// displayed value against the thresholds for the display units
+func (m Model) tempBandColor(celsius float64) lipgloss.Color {
+	degrees, _ := m.displayTemp(celsius)
+	thresholds := tempThresholdsF
+	if m.metric() {
+		thresholds = tempThresholdsC
+	}
+	for i, threshold := range thresholds {
+		if float64(degrees) >= threshold {
+			return tempColors[i]
+		}
+	}
+	return tempCold
+}
+
+// toggleUnits switches the display between imperial and metric units and
+// saves the choice
+func (m Model) toggleUnits() (Model, tea.Cmd) {
+	units := config.UnitsMetric
+	if m.metric() {
+		units = config.UnitsImperial
+	}
+	cmd := m.setPreference(func(p *config.Preferences) {
+		p.Units = units
+	})
+	m.statusMsg = "Units: " + units
+	return m, cmd
+}
+
+// pascalsPerInchHg converts barometric pressure for imperial display
+const pascalsPerInchHg = 3386.389
+
+// formatPressure renders a pressure in pascals as inches of mercury, or
+// hectopascals in metric
+func (m Model) formatPressure(pascals float64) string {
+	if m.metric() {
+		return fmt.Sprintf("%.0f hPa", pascals/100)
+	}
+	return fmt.Sprintf("%.2f inHg", pascals/pascalsPerInchHg)
+}
+
+// formatDistance renders a distance in miles in the display units
+func (m Model) formatDistance(miles float64) string {
+	if m.metric() {
+		return fmt.Sprintf("%.0f km", miles*kmPerMile)
+	}
+	return fmt.Sprintf("%.0f mi", miles)
+}
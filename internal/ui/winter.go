@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/weather"
+)
+
+// winterColor matches the winter alert banner
+const winterColor = lipgloss.Color("51")
+
+// formatSnow renders a depth in millimetres as inches, or centimetres in
+// metric, rounding large snow depths to whole units
+func (m Model) formatSnow(mm float64) string {
+	if m.metric() {
+		if mm >= 100 {
+			return fmt.Sprintf("%.0f cm", mm/10)
+		}
+		return fmt.Sprintf("%.1f cm", mm/10)
+	}
+	inches := mm / mmPerInch
+	if inches >= 10 {
+		return fmt.Sprintf("%.0f in", inches)
+	}
+	return fmt.Sprintf("%.1f in", inches)
+}
+
+// renderWinter draws the snow depth and the coming day's snowfall and ice in
+// one line, or nothing when winter data wasn't fetched or came back empty
+func (m Model) renderWinter() string {
+	winter := m.radar.Winter
+	if winter.Empty() {
+		return ""
+	}
+
+	var parts []string
+	if depth := winter.SnowDepthMM; depth != nil && *depth > 0 {
+		parts = append(parts, "On ground "+m.formatSnow(*depth))
+	}
+	if snowfall := winter.SnowfallMM; snowfall != nil {
+		parts = append(parts, fmt.Sprintf("Next %dh snow %s", weather.WinterHours, m.formatSnow(*snowfall)))
+	}
+	if ice := winter.IceMM; ice != nil && *ice > 0 {
+		parts = append(parts, "ice "+m.formatSnow(*ice))
+	}
+
+	title := lipgloss.NewStyle().Foreground(winterColor).Bold(true).Render("❄️ Winter")
+	return title + config.HelpStyle.Render("  "+strings.Join(parts, " • "))
+}
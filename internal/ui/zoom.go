@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxZoomSteps bounds zooming to four times closer or further than the
+// standard view, each step halving or doubling the ground covered
+const maxZoomSteps = 2
+
+// zoomFactor returns how many times the standard view's ground the current
+// zoom covers: under 1 when zoomed in
+func (m Model) zoomFactor() float64 {
+	return math.Pow(2, float64(m.zoomSteps))
+}
+
+// zoom moves one step in (dir -1) or out (dir 1) and reloads the radar for
+// the new view, doing nothing past either end
+func (m Model) zoom(dir int) (Model, tea.Cmd) {
+	steps := m.zoomSteps + dir
+	if steps < -maxZoomSteps || steps > maxZoomSteps {
+		return m, nil
+	}
+	m.zoomSteps = steps
+	m, cmd := m.startLoad()
+	m.statusMsg = "Zoom: " + m.zoomName()
+	return m, cmd
+}
+
+// zoomName describes the zoom relative to the standard view
+func (m Model) zoomName() string {
+	switch {
+	case m.zoomSteps < 0:
+		return fmt.Sprintf("%.0f× closer", 1/m.zoomFactor())
+	case m.zoomSteps > 0:
+		return fmt.Sprintf("%.0f× wider", m.zoomFactor())
+	}
+	return "standard"
+}
@@ -1,16 +1,20 @@
 package weather
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"net/http"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/N-Erickson/termidar/internal/httpclient"
 )
 
 // Alert represents a weather alert
@@ -21,6 +25,13 @@ type Alert struct {
 	Headline    string
 	Description string
 	Expires     time.Time
+	// Onset is when the hazard begins and Ends when it's over; either is zero
+	// when the alert didn't give one
+	Onset time.Time
+	Ends  time.Time
+	// Polygon is the warning area as [lat, lon] pairs, nil for alerts issued
+	// by zone rather than by polygon
+	Polygon [][]float64
 }
 
 // GetEmoji returns the appropriate emoji for weather conditions
@@ -65,22 +76,18 @@ func GetEmoji(conditions string) string {
 	}
 }
 
-// GetAlertDisplay returns emoji, color, and text for weather alerts
-func GetAlertDisplay(alerts []Alert) (emoji string, color lipgloss.Color, text string) {
-	if len(alerts) == 0 {
-		return "", lipgloss.Color(""), ""
-	}
+// severityRank orders NWS alert severities from least to most severe
+var severityRank = map[string]int{
+	"Extreme":  4,
+	"Severe":   3,
+	"Moderate": 2,
+	"Minor":    1,
+	"Unknown":  0,
+}
 
-	// Find the most severe alert
+// MostSevereAlert returns the highest severity alert, the first one on ties
+func MostSevereAlert(alerts []Alert) Alert {
 	var mostSevere Alert
-	severityRank := map[string]int{
-		"Extreme":  4,
-		"Severe":   3,
-		"Moderate": 2,
-		"Minor":    1,
-		"Unknown":  0,
-	}
-
 	maxSeverity := -1
 	for _, alert := range alerts {
 		rank := severityRank[alert.Severity]
@@ -89,6 +96,38 @@ func GetAlertDisplay(alerts []Alert) (emoji string, color lipgloss.Color, text s
 			mostSevere = alert
 		}
 	}
+	return mostSevere
+}
+
+// Active reports whether the alert is in effect at now: it has begun and
+// neither it nor the hazard it describes is over
+func (a Alert) Active(now time.Time) bool {
+	if !a.Onset.IsZero() && a.Onset.After(now) {
+		return false
+	}
+	if !a.Ends.IsZero() && !a.Ends.After(now) {
+		return false
+	}
+	return a.Expires.IsZero() || a.Expires.After(now)
+}
+
+// SortBySeverity returns the alerts most severe first, keeping the NWS order
+// among alerts of the same severity
+func SortBySeverity(alerts []Alert) []Alert {
+	sorted := append([]Alert(nil), alerts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank[sorted[i].Severity] > severityRank[sorted[j].Severity]
+	})
+	return sorted
+}
+
+// GetAlertDisplay returns emoji, color, and text for weather alerts
+func GetAlertDisplay(alerts []Alert) (emoji string, color lipgloss.Color, text string) {
+	if len(alerts) == 0 {
+		return "", lipgloss.Color(""), ""
+	}
+
+	mostSevere := MostSevereAlert(alerts)
 
 	// Determine emoji and color based on event type and severity
 	switch {
@@ -153,25 +192,36 @@ func getAlertType(event string) string {
 	}
 }
 
-// FetchAlerts fetches weather alerts for the given coordinates
-func FetchAlerts(lat, lon float64) []Alert {
-	client := &http.Client{Timeout: 5 * time.Second}
+// FetchAlerts is FetchActiveAlerts for display, logging rather than
+// returning the error; there are no alerts if the NWS didn't answer
+func FetchAlerts(ctx context.Context, lat, lon float64) []Alert {
+	alerts, err := FetchActiveAlerts(ctx, lat, lon)
+	if err != nil {
+		log.Printf("Failed to fetch weather alerts: %v", err)
+	}
+	return alerts
+}
+
+// FetchActiveAlerts fetches the weather alerts in effect at the given
+// coordinates
+func FetchActiveAlerts(ctx context.Context, lat, lon float64) ([]Alert, error) {
+	client := httpclient.New(httpclient.APITimeout)
 
 	alertsURL := fmt.Sprintf("https://api.weather.gov/alerts/active?point=%.4f,%.4f", lat, lon)
 
-	resp, err := client.Get(alertsURL)
+	resp, err := httpclient.Fetch(ctx, client, alertsURL)
 	if err != nil {
-		log.Printf("Failed to fetch weather alerts: %v", err)
-		return nil
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil
+		return nil, fmt.Errorf("NWS alerts API returned status: %d", resp.StatusCode)
 	}
 
 	var alertsData struct {
 		Features []struct {
+			Geometry   json.RawMessage `json:"geometry"`
 			Properties struct {
 				Event       string    `json:"event"`
 				Severity    string    `json:"severity"`
@@ -179,15 +229,19 @@ func FetchAlerts(lat, lon float64) []Alert {
 				Headline    string    `json:"headline"`
 				Description string    `json:"description"`
 				Expires     time.Time `json:"expires"`
+				Onset       time.Time `json:"onset"`
+				Ends        time.Time `json:"ends"`
 			} `json:"properties"`
 		} `json:"features"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&alertsData); err != nil {
-		log.Printf("Failed to decode alerts: %v", err)
-		return nil
+		return nil, fmt.Errorf("failed to decode alerts: %w", err)
 	}
 
+	// The active-alerts endpoint can still list alerts that have expired or
+	// are issued ahead of time, so only those in effect now are kept
+	now := time.Now()
 	var alerts []Alert
 	for _, feature := range alertsData.Features {
 		alert := Alert{
@@ -197,29 +251,109 @@ func FetchAlerts(lat, lon float64) []Alert {
 			Headline:    feature.Properties.Headline,
 			Description: feature.Properties.Description,
 			Expires:     feature.Properties.Expires,
+			Onset:       feature.Properties.Onset,
+			Ends:        feature.Properties.Ends,
+			Polygon:     parsePolygon(feature.Geometry),
+		}
+		if alert.Active(now) {
+			alerts = append(alerts, alert)
 		}
-		alerts = append(alerts, alert)
 	}
 
-	return alerts
+	return alerts, nil
+}
+
+// Observation is the current conditions reported by one station
+type Observation struct {
+	StationID string
+	// Celsius is the temperature as the station reported it, so metric
+	// display doesn't round-trip through Fahrenheit; nil when it was null
+	Celsius    *float64
+	Conditions string
+	// Humidity is the relative humidity in percent, DewpointC the dew point
+	// in Celsius and PressurePa the barometric pressure in pascals; each is
+	// nil when the station left it null
+	Humidity   *float64
+	DewpointC  *float64
+	PressurePa *float64
+}
+
+// ErrNoTemperature is returned with a partial observation when stations
+// answered but none reported a temperature
+var ErrNoTemperature = errors.New("no temperature reported")
+
+// FetchConditions is FetchObservation for display, logging rather than
+// returning the error; the observation is empty if no station answered
+func FetchConditions(ctx context.Context, lat, lon float64, maxStations int) Observation {
+	obs, err := FetchObservation(ctx, lat, lon, maxStations)
+	if err != nil {
+		log.Printf("No current conditions: %v", err)
+	}
+	return obs
 }
 
-// FetchCurrentConditions fetches current weather conditions for the given coordinates
-func FetchCurrentConditions(lat, lon float64) (int, string) {
-	client := &http.Client{Timeout: 5 * time.Second}
+// FetchObservation walks the observation stations nearest the coordinates and
+// returns the first complete observation. If no station reports a temperature,
+// the first partial observation is returned along with an error. The walk
+// stops once ctx is done.
+func FetchObservation(ctx context.Context, lat, lon float64, maxStations int) (Observation, error) {
+	client := httpclient.New(httpclient.APITimeout)
+
+	stations, err := fetchObservationStations(ctx, client, lat, lon)
+	if err != nil {
+		return Observation{}, err
+	}
+
+	// The nearest station is often stale or reporting nulls, so move down the
+	// list until one reports a temperature
+	if maxStations < 1 {
+		maxStations = 1
+	}
+	if maxStations > len(stations) {
+		maxStations = len(stations)
+	}
+
+	var partial *Observation
+	for i, stationID := range stations[:maxStations] {
+		if ctx.Err() != nil {
+			break
+		}
+		// Transient failures were already retried by the fetch
+		obs, ok := fetchLatestObservation(ctx, client, stationID)
+		if !ok {
+			continue
+		}
+
+		obs.StationID = stationID
+		if obs.Celsius != nil {
+			log.Printf("Using observation from %s after trying %d of %d stations", stationID, i+1, len(stations))
+			return obs, nil
+		}
+		if partial == nil {
+			partial = &obs
+		}
+	}
 
+	log.Printf("No complete observation after trying %d of %d stations", maxStations, len(stations))
+	if partial != nil {
+		return *partial, fmt.Errorf("%w by %d stations", ErrNoTemperature, maxStations)
+	}
+	return Observation{}, fmt.Errorf("no observations from %d stations", maxStations)
+}
+
+// fetchObservationStations returns the observation station IDs for a point,
+// nearest first (private helper)
+func fetchObservationStations(ctx context.Context, client *http.Client, lat, lon float64) ([]string, error) {
 	pointURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
 
-	resp, err := client.Get(pointURL)
+	resp, err := httpclient.Fetch(ctx, client, pointURL)
 	if err != nil {
-		log.Printf("Failed to get NWS point data: %v", err)
-		return 0, ""
+		return nil, fmt.Errorf("failed to get NWS point data: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		log.Printf("NWS point API returned status: %d", resp.StatusCode)
-		return 0, ""
+		return nil, fmt.Errorf("NWS point API returned status: %d", resp.StatusCode)
 	}
 
 	var pointData struct {
@@ -230,14 +364,12 @@ func FetchCurrentConditions(lat, lon float64) (int, string) {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&pointData); err != nil {
-		log.Printf("Failed to decode NWS point data: %v", err)
-		return 0, ""
+		return nil, fmt.Errorf("failed to decode NWS point data: %w", err)
 	}
 
-	stationsResp, err := client.Get(pointData.Properties.ObservationURL)
+	stationsResp, err := httpclient.Fetch(ctx, client, pointData.Properties.ObservationURL)
 	if err != nil {
-		log.Printf("Failed to get observation stations: %v", err)
-		return 0, ""
+		return nil, fmt.Errorf("failed to get observation stations: %w", err)
 	}
 	defer stationsResp.Body.Close()
 
@@ -250,178 +382,106 @@ func FetchCurrentConditions(lat, lon float64) (int, string) {
 	}
 
 	if err := json.NewDecoder(stationsResp.Body).Decode(&stationsData); err != nil {
-		log.Printf("Failed to decode stations data: %v", err)
-		return 0, ""
+		return nil, fmt.Errorf("failed to decode stations data: %w", err)
 	}
 
 	if len(stationsData.Features) == 0 {
-		log.Printf("No observation stations found")
-		return 0, ""
-	}
-
-	stationID := stationsData.Features[0].Properties.StationIdentifier
-	obsURL := fmt.Sprintf("https://api.weather.gov/stations/%s/observations/latest", stationID)
-
-	obsResp, err := client.Get(obsURL)
-	if err != nil {
-		log.Printf("Failed to get observations: %v", err)
-		return 0, ""
+		return nil, fmt.Errorf("no observation stations found")
 	}
-	defer obsResp.Body.Close()
 
-	var obsData struct {
-		Properties struct {
-			Temperature struct {
-				Value    float64 `json:"value"`
-				UnitCode string  `json:"unitCode"`
-			} `json:"temperature"`
-			TextDescription string `json:"textDescription"`
-		} `json:"properties"`
+	stations := make([]string, len(stationsData.Features))
+	for i, feature := range stationsData.Features {
+		stations[i] = feature.Properties.StationIdentifier
 	}
-
-	if err := json.NewDecoder(obsResp.Body).Decode(&obsData); err != nil {
-		log.Printf("Failed to decode observation data: %v", err)
-		return 0, ""
-	}
-
-	temp := obsData.Properties.Temperature.Value
-	unitCode := obsData.Properties.Temperature.UnitCode
-	
-	// Log for debugging
-	log.Printf("Temperature value: %f, unit: %s", temp, unitCode)
-	
-	// Check for Celsius in various formats the API might return
-	if strings.Contains(strings.ToLower(unitCode), "degc") || 
-	   strings.Contains(strings.ToLower(unitCode), "celsius") ||
-	   unitCode == "wmoUnit:degC" ||
-	   unitCode == "unit:degC" {
-		temp = temp*9/5 + 32
-		log.Printf("Converted from Celsius to Fahrenheit: %f", temp)
-	}
-
-	conditions := obsData.Properties.TextDescription
-	if conditions == "" {
-		conditions = "Clear"
-	}
-
-	return int(temp), conditions
+	return stations, nil
 }
 
-// GeocodeZip converts a ZIP code to coordinates and location information
-func GeocodeZip(zipCode string) (float64, float64, string, string, error) {
-	url := fmt.Sprintf("https://api.zippopotam.us/us/%s", zipCode)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
-	if err != nil {
-		return geocodeZipAlternative(zipCode)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return geocodeZipAlternative(zipCode)
-	}
-
-	var result struct {
-		PostCode    string `json:"post code"`
-		Country     string `json:"country"`
-		CountryCode string `json:"country abbreviation"`
-		Places      []struct {
-			PlaceName  string  `json:"place name"`
-			State      string  `json:"state"`
-			StateCode  string  `json:"state abbreviation"`
-			Latitude   string  `json:"latitude"`
-			Longitude  string  `json:"longitude"`
-		} `json:"places"`
-	}
+// observationValue is one measurement in an NWS observation, whose value is
+// null when the station didn't report it
+type observationValue struct {
+	Value    *float64 `json:"value"`
+	UnitCode string   `json:"unitCode"`
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return geocodeZipAlternative(zipCode)
+// Celsius returns a temperature measurement in Celsius, or nil if it was
+// null. NWS reports Celsius; convert only if a station ever sends Fahrenheit.
+func (v observationValue) Celsius() *float64 {
+	if v.Value == nil {
+		return nil
 	}
-
-	if len(result.Places) == 0 {
-		return geocodeZipAlternative(zipCode)
+	temp := *v.Value
+	unitCode := strings.ToLower(v.UnitCode)
+	if strings.Contains(unitCode, "degf") || strings.Contains(unitCode, "fahrenheit") {
+		temp = (temp - 32) * 5 / 9
 	}
+	return &temp
+}
 
-	place := result.Places[0]
+// fetchLatestObservation reads a station's latest observation, with
+// temperatures in Celsius. ok is false when the observation couldn't be
+// fetched at all.
+func fetchLatestObservation(ctx context.Context, client *http.Client, stationID string) (Observation, bool) {
+	obsURL := fmt.Sprintf("https://api.weather.gov/stations/%s/observations/latest", stationID)
 
-	lat, err := strconv.ParseFloat(place.Latitude, 64)
+	obsResp, err := httpclient.Fetch(ctx, client, obsURL)
 	if err != nil {
-		return 0, 0, "", "", fmt.Errorf("invalid latitude for ZIP %s", zipCode)
+		log.Printf("Failed to get observations: %v", err)
+		return Observation{}, false
 	}
+	defer obsResp.Body.Close()
 
-	lon, err := strconv.ParseFloat(place.Longitude, 64)
-	if err != nil {
-		return 0, 0, "", "", fmt.Errorf("invalid longitude for ZIP %s", zipCode)
+	// An error page decodes to empty properties, which would pass for clear
+	// skies with no temperature
+	if obsResp.StatusCode != http.StatusOK {
+		log.Printf("Station %s observation returned status %d", stationID, obsResp.StatusCode)
+		return Observation{}, false
 	}
 
-	return lat, lon, place.PlaceName, place.StateCode, nil
-}
-
-// geocodeZipAlternative provides a fallback geocoding service (private helper)
-func geocodeZipAlternative(zipCode string) (float64, float64, string, string, error) {
-	url := fmt.Sprintf("https://api.geocod.io/v1.7/geocode?q=%s&api_key=demo", zipCode)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
-	if err != nil {
-		return 0, 0, "", "", fmt.Errorf("failed to geocode ZIP %s: %w", zipCode, err)
+	var obsData struct {
+		Properties struct {
+			Temperature        observationValue `json:"temperature"`
+			Dewpoint           observationValue `json:"dewpoint"`
+			RelativeHumidity   observationValue `json:"relativeHumidity"`
+			BarometricPressure observationValue `json:"barometricPressure"`
+			TextDescription    string           `json:"textDescription"`
+		} `json:"properties"`
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, 0, "", "", fmt.Errorf("unable to find location for ZIP %s", zipCode)
+	if err := json.NewDecoder(obsResp.Body).Decode(&obsData); err != nil {
+		log.Printf("Failed to decode observation data: %v", err)
+		return Observation{}, false
 	}
 
-	var result struct {
-		Results []struct {
-			AddressComponents struct {
-				City  string `json:"city"`
-				State string `json:"state"`
-			} `json:"address_components"`
-			Location struct {
-				Lat float64 `json:"lat"`
-				Lng float64 `json:"lng"`
-			} `json:"location"`
-		} `json:"results"`
+	props := obsData.Properties
+	obs := Observation{
+		Conditions: props.TextDescription,
+		DewpointC:  props.Dewpoint.Celsius(),
+		Humidity:   props.RelativeHumidity.Value,
+		PressurePa: props.BarometricPressure.Value,
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return 0, 0, "", "", fmt.Errorf("failed to decode geocoding response: %w", err)
+	if obs.Conditions == "" {
+		obs.Conditions = "Clear"
 	}
 
-	if len(result.Results) == 0 {
-		return 0, 0, "", "", fmt.Errorf("no results found for ZIP %s", zipCode)
+	// NWS frequently reports null temperatures; keep that distinct from 0°
+	obs.Celsius = props.Temperature.Celsius()
+	if obs.Celsius == nil {
+		log.Printf("Station %s reported no temperature", stationID)
 	}
-
-	r := result.Results[0]
-	return r.Location.Lat, r.Location.Lng, r.AddressComponents.City, r.AddressComponents.State, nil
+	return obs, true
 }
 
 // GetNearestRadarStation returns the nearest NWS radar station for given coordinates
 func GetNearestRadarStation(lat, lon float64) (string, error) {
-	stations := []struct {
-		id   string
-		lat  float64
-		lon  float64
-	}{
-		{"KOKX", 40.8653, -72.8639},  // New York
-		{"KLOT", 41.6045, -88.0847},  // Chicago
-		{"KAMX", 25.6111, -80.4128},  // Miami
-		{"KATX", 48.1945, -122.4958}, // Seattle
-		{"KFWS", 32.5731, -97.3031},  // Dallas
-		{"KLVX", 37.9753, -85.9439},  // Louisville
-		{"KTFX", 47.4595, -111.3855}, // Great Falls
-		{"KSGF", 37.2355, -93.4003},  // Springfield
-		{"KLAS", 36.0558, -115.1622}, // Las Vegas
-		{"KPHX", 33.4301, -112.0128}, // Phoenix
-	}
-
-	minDist := 999999.0
+	// Compare distances on a flat mile grid, scaling longitude for the
+	// latitude so east-west neighbors aren't favored away from the equator
+	milesPerDegreeLon := MilesPerDegreeLat * math.Cos(lat*math.Pi/180)
+
+	minDist := math.Inf(1)
 	nearest := "KOKX"
 
-	for _, s := range stations {
-		dist := math.Sqrt(math.Pow(lat-s.lat, 2) + math.Pow(lon-s.lon, 2))
+	for _, s := range nexradStations {
+		dist := math.Hypot((s.lat-lat)*MilesPerDegreeLat, (s.lon-lon)*milesPerDegreeLon)
 		if dist < minDist {
 			minDist = dist
 			nearest = s.id
@@ -0,0 +1,55 @@
+package weather
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/fixtures"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+func TestAlertActive(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	hour := time.Hour
+
+	tests := []struct {
+		name  string
+		alert Alert
+		want  bool
+	}{
+		{"no times", Alert{}, true},
+		{"in effect", Alert{Onset: now.Add(-hour), Ends: now.Add(hour), Expires: now.Add(hour)}, true},
+		{"expired", Alert{Onset: now.Add(-2 * hour), Expires: now.Add(-hour)}, false},
+		{"expires now", Alert{Expires: now}, false},
+		{"hazard over", Alert{Ends: now.Add(-hour), Expires: now.Add(hour)}, false},
+		{"not begun", Alert{Onset: now.Add(3 * hour), Expires: now.Add(hour)}, false},
+		{"begins now", Alert{Onset: now, Expires: now.Add(hour)}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.alert.Active(now); got != tt.want {
+			t.Errorf("%s: Active = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFetchAlertsKeepsActive(t *testing.T) {
+	saved := httpclient.Transport
+	httpclient.Transport = fixtures.Transport()
+	defer func() { httpclient.Transport = saved }()
+
+	// The fixture lists a current Flood Watch, a Wind Advisory that has
+	// expired and a Heat Advisory that hasn't begun
+	alerts := FetchAlerts(context.Background(), 40.7831, -73.9712)
+	if len(alerts) != 1 || alerts[0].Event != "Flood Watch" {
+		var events []string
+		for _, alert := range alerts {
+			events = append(events, alert.Event)
+		}
+		t.Fatalf("alerts = %q, want only the Flood Watch", events)
+	}
+	if alerts[0].Onset.IsZero() || alerts[0].Ends.IsZero() {
+		t.Errorf("onset %v and ends %v should be parsed", alerts[0].Onset, alerts[0].Ends)
+	}
+}
@@ -0,0 +1,119 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// ForecastPeriods is how many of the forecast's day and night periods are
+// returned
+const ForecastPeriods = 4
+
+// ForecastPeriod is one day or night of the NWS forecast
+type ForecastPeriod struct {
+	// Name is the forecast's own label, such as "Tonight" or "Thursday"
+	Name string
+	// Celsius is the forecast high or low, converted from Fahrenheit when
+	// the NWS reports that
+	Celsius float64
+	// Short is the one-line summary, such as "Chance Rain Showers"
+	Short string
+}
+
+// forecastURLs are the forecast links the points API gives for a location
+type forecastURLs struct {
+	ForecastURL       string `json:"forecast"`
+	ForecastHourlyURL string `json:"forecastHourly"`
+}
+
+// fetchForecastURLs looks up a point's forecast links (private helper)
+func fetchForecastURLs(ctx context.Context, client *http.Client, lat, lon float64) (forecastURLs, error) {
+	pointURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
+	resp, err := httpclient.Fetch(ctx, client, pointURL)
+	if err != nil {
+		return forecastURLs{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return forecastURLs{}, fmt.Errorf("points API returned status %d", resp.StatusCode)
+	}
+
+	var pointData struct {
+		Properties forecastURLs `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pointData); err != nil {
+		return forecastURLs{}, err
+	}
+	return pointData.Properties, nil
+}
+
+// FetchForecast returns the next ForecastPeriods periods of the NWS forecast
+// at a point, soonest first
+func FetchForecast(ctx context.Context, lat, lon float64) ([]ForecastPeriod, error) {
+	client := httpclient.New(httpclient.APITimeout)
+
+	point, err := fetchForecastURLs(ctx, client, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	if point.ForecastURL == "" {
+		return nil, fmt.Errorf("no forecast for this point")
+	}
+
+	resp, err := httpclient.Fetch(ctx, client, point.ForecastURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("forecast returned status %d", resp.StatusCode)
+	}
+
+	var forecast struct {
+		Properties struct {
+			Periods []struct {
+				Name            string    `json:"name"`
+				EndTime         time.Time `json:"endTime"`
+				Temperature     *float64  `json:"temperature"`
+				TemperatureUnit string    `json:"temperatureUnit"`
+				ShortForecast   string    `json:"shortForecast"`
+			} `json:"periods"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&forecast); err != nil {
+		return nil, err
+	}
+
+	// Skip periods already over, which the forecast keeps until it's
+	// reissued
+	now := time.Now()
+	var periods []ForecastPeriod
+	for _, period := range forecast.Properties.Periods {
+		if period.Temperature == nil || (!period.EndTime.IsZero() && period.EndTime.Before(now)) {
+			continue
+		}
+
+		celsius := *period.Temperature
+		if !strings.EqualFold(period.TemperatureUnit, "C") {
+			celsius = (celsius - 32) * 5 / 9
+		}
+		periods = append(periods, ForecastPeriod{Name: period.Name, Celsius: celsius, Short: period.ShortForecast})
+
+		if len(periods) >= ForecastPeriods {
+			break
+		}
+	}
+
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("no forecast periods")
+	}
+	return periods, nil
+}
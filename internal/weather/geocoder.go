@@ -0,0 +1,406 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/cache"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// ErrLocationNotFound is returned when a geocoder has no match for a query.
+// It means the query is wrong, not the provider, so it doesn't count against
+// the provider's health.
+var ErrLocationNotFound = errors.New("location not found")
+
+// Location is a geocoded place
+type Location struct {
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+	City  string  `json:"city"`
+	State string  `json:"state"`
+	// Source names the provider that resolved the location
+	Source string `json:"source,omitempty"`
+}
+
+// Geocoder resolves a query to a location
+type Geocoder interface {
+	// Name identifies the provider in logs and status displays
+	Name() string
+	// Geocode resolves query, giving up once ctx is done
+	Geocode(ctx context.Context, query string) (Location, error)
+}
+
+// ZippopotamGeocoder looks ZIP codes up with the free zippopotam.us API
+type ZippopotamGeocoder struct{}
+
+// Name returns the provider name
+func (ZippopotamGeocoder) Name() string { return "zippopotam.us" }
+
+// Geocode looks up a US ZIP code, or a "City, ST" place through the
+// state/city endpoint
+func (ZippopotamGeocoder) Geocode(ctx context.Context, query string) (Location, error) {
+	endpoint := fmt.Sprintf("https://api.zippopotam.us/us/%s", query)
+	if !IsZipCode(query) {
+		city, state, ok := parsePlace(query)
+		if !ok {
+			return Location{}, ErrLocationNotFound
+		}
+		endpoint = fmt.Sprintf("https://api.zippopotam.us/us/%s/%s",
+			strings.ToLower(state), url.PathEscape(strings.ToLower(city)))
+	}
+
+	client := httpclient.New(httpclient.ServiceTimeout)
+	resp, err := httpclient.Fetch(ctx, client, endpoint)
+	if err != nil {
+		return Location{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return Location{}, ErrLocationNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Location{}, fmt.Errorf("zippopotam.us returned status %d", resp.StatusCode)
+	}
+
+	// Place lookups put the state at the top level instead of on each place
+	var result struct {
+		PostCode    string `json:"post code"`
+		Country     string `json:"country"`
+		CountryCode string `json:"country abbreviation"`
+		StateCode   string `json:"state abbreviation"`
+		Places      []struct {
+			PlaceName string `json:"place name"`
+			State     string `json:"state"`
+			StateCode string `json:"state abbreviation"`
+			Latitude  string `json:"latitude"`
+			Longitude string `json:"longitude"`
+		} `json:"places"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Location{}, fmt.Errorf("failed to decode zippopotam.us response: %w", err)
+	}
+
+	if len(result.Places) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	place := result.Places[0]
+
+	lat, err := strconv.ParseFloat(place.Latitude, 64)
+	if err != nil {
+		return Location{}, fmt.Errorf("invalid latitude for %s", query)
+	}
+
+	lon, err := strconv.ParseFloat(place.Longitude, 64)
+	if err != nil {
+		return Location{}, fmt.Errorf("invalid longitude for %s", query)
+	}
+
+	state := place.StateCode
+	if state == "" {
+		state = result.StateCode
+	}
+
+	return Location{Lat: lat, Lon: lon, City: place.PlaceName, State: state}, nil
+}
+
+// GeocodioGeocoder looks queries up with geocod.io using its demo key
+type GeocodioGeocoder struct{}
+
+// Name returns the provider name
+func (GeocodioGeocoder) Name() string { return "geocod.io" }
+
+// Geocode looks up a US ZIP code or place name
+func (GeocodioGeocoder) Geocode(ctx context.Context, query string) (Location, error) {
+	endpoint := fmt.Sprintf("https://api.geocod.io/v1.7/geocode?q=%s&api_key=demo", url.QueryEscape(query))
+
+	client := httpclient.New(httpclient.ServiceTimeout)
+	resp, err := httpclient.Fetch(ctx, client, endpoint)
+	if err != nil {
+		return Location{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Location{}, fmt.Errorf("geocod.io returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Results []struct {
+			AddressComponents struct {
+				City  string `json:"city"`
+				State string `json:"state"`
+			} `json:"address_components"`
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"results"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Location{}, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+
+	if len(result.Results) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	r := result.Results[0]
+	return Location{
+		Lat:   r.Location.Lat,
+		Lon:   r.Location.Lng,
+		City:  r.AddressComponents.City,
+		State: r.AddressComponents.State,
+	}, nil
+}
+
+//go:embed data/zipcodes.csv
+var zipCodesCSV []byte
+
+var (
+	offlineOnce  sync.Once
+	offlineTable map[string]Location
+)
+
+// loadOfflineTable parses the embedded ZIP table once (private helper)
+func loadOfflineTable() map[string]Location {
+	offlineOnce.Do(func() {
+		offlineTable = make(map[string]Location)
+
+		records, err := csv.NewReader(bytes.NewReader(zipCodesCSV)).ReadAll()
+		if err != nil {
+			log.Printf("Failed to parse offline ZIP table: %v", err)
+			return
+		}
+
+		// Skip the header row
+		for _, rec := range records[1:] {
+			lat, errLat := strconv.ParseFloat(rec[1], 64)
+			lon, errLon := strconv.ParseFloat(rec[2], 64)
+			if errLat != nil || errLon != nil {
+				continue
+			}
+			offlineTable[rec[0]] = Location{Lat: lat, Lon: lon, City: rec[3], State: rec[4]}
+		}
+	})
+	return offlineTable
+}
+
+// OfflineZipCodes returns the ZIP codes in the embedded table, sorted
+func OfflineZipCodes() []string {
+	table := loadOfflineTable()
+	zips := make([]string, 0, len(table))
+	for zip := range table {
+		zips = append(zips, zip)
+	}
+	sort.Strings(zips)
+	return zips
+}
+
+// OfflineGeocoder resolves ZIP codes from a small embedded table of major
+// cities, so common locations work even when every online provider is down
+type OfflineGeocoder struct{}
+
+// Name returns the provider name
+func (OfflineGeocoder) Name() string { return "offline table" }
+
+// Geocode looks up a ZIP code, or a "City, ST" place, in the embedded table
+func (OfflineGeocoder) Geocode(_ context.Context, query string) (Location, error) {
+	table := loadOfflineTable()
+	if loc, ok := table[query]; ok {
+		return loc, nil
+	}
+
+	city, state, ok := parsePlace(query)
+	if !ok {
+		return Location{}, ErrLocationNotFound
+	}
+	// Walk the ZIP codes in order so a city with several picks the same one
+	for _, zip := range OfflineZipCodes() {
+		if loc := table[zip]; loc.State == state && strings.EqualFold(loc.City, city) {
+			return loc, nil
+		}
+	}
+	return Location{}, ErrLocationNotFound
+}
+
+// FailoverGeocoder tries each provider in priority order. A provider that
+// fails for reasons other than "not found" is skipped for Cooldown so a down
+// service doesn't slow every lookup.
+type FailoverGeocoder struct {
+	Providers []Geocoder
+	Cooldown  time.Duration
+
+	mu        sync.Mutex
+	downUntil map[string]time.Time
+}
+
+// NewFailoverGeocoder creates a failover chain over the given providers
+func NewFailoverGeocoder(cooldown time.Duration, providers ...Geocoder) *FailoverGeocoder {
+	return &FailoverGeocoder{
+		Providers: providers,
+		Cooldown:  cooldown,
+		downUntil: make(map[string]time.Time),
+	}
+}
+
+// Name returns the provider name
+func (f *FailoverGeocoder) Name() string { return "failover" }
+
+// Geocode returns the first successful result from the healthy providers. If
+// every provider is cooling down they are all tried anyway rather than failing
+// outright. A canceled lookup stops the chain without blaming the provider.
+// When a provider couldn't be reached, that is the error returned even if a
+// later one had no match: the offline table is always last and only knows a
+// few places, so its miss says nothing about whether the query exists.
+func (f *FailoverGeocoder) Geocode(ctx context.Context, query string) (Location, error) {
+	healthy := f.healthyProviders()
+	if len(healthy) == 0 {
+		healthy = f.Providers
+	}
+
+	var lastErr, netErr error
+	for _, provider := range healthy {
+		loc, err := provider.Geocode(ctx, query)
+		if ctx.Err() != nil {
+			return Location{}, ctx.Err()
+		}
+		if err == nil {
+			f.markHealthy(provider)
+			if loc.Source == "" {
+				loc.Source = provider.Name()
+			}
+			return loc, nil
+		}
+
+		if !errors.Is(err, ErrLocationNotFound) {
+			log.Printf("Geocoder %s failed, skipping it for %s: %v", provider.Name(), f.Cooldown, err)
+			f.markDown(provider)
+			if netErr == nil && isNetworkError(err) {
+				netErr = err
+			}
+		}
+		lastErr = err
+	}
+
+	if netErr != nil {
+		lastErr = netErr
+	}
+	if lastErr == nil {
+		lastErr = ErrLocationNotFound
+	}
+	return Location{}, fmt.Errorf("unable to find location for %s: %w", query, lastErr)
+}
+
+// isNetworkError reports whether err means a service couldn't be reached or
+// didn't answer in time (private helper)
+func isNetworkError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// healthyProviders returns the providers not currently cooling down (private helper)
+func (f *FailoverGeocoder) healthyProviders() []Geocoder {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var healthy []Geocoder
+	now := time.Now()
+	for _, provider := range f.Providers {
+		if now.After(f.downUntil[provider.Name()]) {
+			healthy = append(healthy, provider)
+		}
+	}
+	return healthy
+}
+
+func (f *FailoverGeocoder) markDown(provider Geocoder) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.downUntil[provider.Name()] = time.Now().Add(f.Cooldown)
+}
+
+func (f *FailoverGeocoder) markHealthy(provider Geocoder) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.downUntil, provider.Name())
+}
+
+// CachedGeocoder consults the disk cache before asking the wrapped geocoder
+// and stores whatever it resolves
+type CachedGeocoder struct {
+	Geocoder Geocoder
+}
+
+// Name returns the wrapped provider's name
+func (c CachedGeocoder) Name() string { return c.Geocoder.Name() }
+
+// Geocode returns a cached location when one is fresh enough
+func (c CachedGeocoder) Geocode(ctx context.Context, query string) (Location, error) {
+	var loc Location
+	if cache.Get(cache.KindGeocode, query, &loc) {
+		return loc, nil
+	}
+
+	loc, err := c.Geocoder.Geocode(ctx, query)
+	if err != nil {
+		return Location{}, err
+	}
+
+	cache.Put(cache.KindGeocode, query, loc)
+	return loc, nil
+}
+
+// geocoderProviders are the default chain's providers in priority order
+var geocoderProviders = []Geocoder{
+	ZippopotamGeocoder{},
+	GeocodioGeocoder{},
+	OfflineGeocoder{},
+}
+
+// geocoderCooldown is how long a failing provider is skipped
+const geocoderCooldown = 5 * time.Minute
+
+// DefaultGeocoder is the cached provider chain used when no other geocoder is given
+var DefaultGeocoder Geocoder = CachedGeocoder{
+	Geocoder: NewFailoverGeocoder(geocoderCooldown, geocoderProviders...),
+}
+
+// AlternateGeocoder returns a chain over the default providers except the
+// named one, starting from the provider after it, for a second opinion on a
+// location. Its answers aren't cached, so they never replace the first one.
+func AlternateGeocoder(current string) Geocoder {
+	start := 0
+	for i, provider := range geocoderProviders {
+		if provider.Name() == current {
+			start = i + 1
+		}
+	}
+
+	var others []Geocoder
+	for i := range geocoderProviders {
+		provider := geocoderProviders[(start+i)%len(geocoderProviders)]
+		if provider.Name() != current {
+			others = append(others, provider)
+		}
+	}
+	return NewFailoverGeocoder(geocoderCooldown, others...)
+}
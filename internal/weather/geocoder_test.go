@@ -0,0 +1,54 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// stubGeocoder fails every lookup with err
+type stubGeocoder struct {
+	name string
+	err  error
+}
+
+func (s stubGeocoder) Name() string { return s.name }
+
+func (s stubGeocoder) Geocode(context.Context, string) (Location, error) {
+	return Location{}, s.err
+}
+
+func TestFailoverGeocoderKeepsNetworkError(t *testing.T) {
+	unreachable := &url.Error{Op: "Get", URL: "https://api.zippopotam.us/us/59999", Err: &net.OpError{
+		Op: "dial", Net: "tcp", Err: errors.New("connect: network is unreachable"),
+	}}
+	if _, ok := loadOfflineTable()["59999"]; ok {
+		t.Fatal("59999 is in the offline table; pick a ZIP it doesn't know")
+	}
+
+	tests := []struct {
+		name      string
+		providers []Geocoder
+		wantNet   bool
+	}{
+		{"outage then offline miss", []Geocoder{stubGeocoder{"zip", unreachable}, OfflineGeocoder{}}, true},
+		{"timeout then offline miss", []Geocoder{stubGeocoder{"zip", context.DeadlineExceeded}, OfflineGeocoder{}}, true},
+		{"misconfigured then offline miss", []Geocoder{stubGeocoder{"zip", errors.New("no API key")}, OfflineGeocoder{}}, false},
+		{"every provider misses", []Geocoder{stubGeocoder{"zip", ErrLocationNotFound}, OfflineGeocoder{}}, false},
+	}
+
+	for _, tt := range tests {
+		_, err := NewFailoverGeocoder(time.Minute, tt.providers...).Geocode(context.Background(), "59999")
+		var netErr net.Error
+		isNet := errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+		if isNet != tt.wantNet {
+			t.Errorf("%s: network error %t, want %t (%v)", tt.name, isNet, tt.wantNet, err)
+		}
+		if notFound := errors.Is(err, ErrLocationNotFound); notFound == tt.wantNet {
+			t.Errorf("%s: not found %t (%v)", tt.name, notFound, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// HourlyHours is how far ahead the hourly outlook reaches
+const HourlyHours = 12
+
+// HourlyPeriod is one hour of the NWS hourly forecast
+type HourlyPeriod struct {
+	Start time.Time
+	// Celsius is the forecast temperature, converted from Fahrenheit when
+	// the NWS reports that
+	Celsius float64
+	// PrecipChance is the probability of precipitation in percent, nil when
+	// the forecast leaves it out
+	PrecipChance *int
+}
+
+// FetchHourlyForecast returns the NWS hourly forecast for the next
+// HourlyHours hours at a point, soonest first
+func FetchHourlyForecast(ctx context.Context, lat, lon float64) ([]HourlyPeriod, error) {
+	client := httpclient.New(httpclient.APITimeout)
+
+	point, err := fetchForecastURLs(ctx, client, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	if point.ForecastHourlyURL == "" {
+		return nil, fmt.Errorf("no hourly forecast for this point")
+	}
+
+	hourlyResp, err := httpclient.Fetch(ctx, client, point.ForecastHourlyURL)
+	if err != nil {
+		return nil, err
+	}
+	defer hourlyResp.Body.Close()
+
+	if hourlyResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hourly forecast returned status %d", hourlyResp.StatusCode)
+	}
+
+	var hourly struct {
+		Properties struct {
+			Periods []struct {
+				StartTime                  time.Time `json:"startTime"`
+				Temperature                *float64  `json:"temperature"`
+				TemperatureUnit            string    `json:"temperatureUnit"`
+				ProbabilityOfPrecipitation struct {
+					Value *float64 `json:"value"`
+				} `json:"probabilityOfPrecipitation"`
+			} `json:"periods"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(hourlyResp.Body).Decode(&hourly); err != nil {
+		return nil, err
+	}
+
+	// Skip hours already over, which the forecast keeps until it's reissued
+	cutoff := time.Now().Add(-time.Hour)
+	var periods []HourlyPeriod
+	for _, period := range hourly.Properties.Periods {
+		if period.Temperature == nil || period.StartTime.Before(cutoff) {
+			continue
+		}
+
+		celsius := *period.Temperature
+		if !strings.EqualFold(period.TemperatureUnit, "C") {
+			celsius = (celsius - 32) * 5 / 9
+		}
+
+		p := HourlyPeriod{Start: period.StartTime, Celsius: celsius}
+		if value := period.ProbabilityOfPrecipitation.Value; value != nil {
+			chance := int(*value)
+			p.PrecipChance = &chance
+		}
+		periods = append(periods, p)
+
+		if len(periods) >= HourlyHours {
+			break
+		}
+	}
+
+	if len(periods) == 0 {
+		return nil, fmt.Errorf("no hourly periods")
+	}
+	return periods, nil
+}
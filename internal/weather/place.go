@@ -0,0 +1,69 @@
+package weather
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidPlace is returned for a query that is neither a ZIP code nor a
+// "City, ST" place name
+var ErrInvalidPlace = errors.New(`enter a 5-digit ZIP code or a place like "Boulder, CO"`)
+
+// stateCodes are the two-letter abbreviations a place name can end with
+var stateCodes = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true,
+	"CT": true, "DE": true, "DC": true, "FL": true, "GA": true, "HI": true,
+	"ID": true, "IL": true, "IN": true, "IA": true, "KS": true, "KY": true,
+	"LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true,
+	"MS": true, "MO": true, "MT": true, "NE": true, "NV": true, "NH": true,
+	"NJ": true, "NM": true, "NY": true, "NC": true, "ND": true, "OH": true,
+	"OK": true, "OR": true, "PA": true, "PR": true, "RI": true, "SC": true,
+	"SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true,
+	"WA": true, "WV": true, "WI": true, "WY": true,
+}
+
+// IsZipCode reports whether query is a five-digit ZIP code
+func IsZipCode(query string) bool {
+	return len(query) == 5 && allDigits(query)
+}
+
+// allDigits reports whether s is only ASCII digits (private helper)
+func allDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parsePlace splits "City, ST" (or "City ST") into the city and the
+// upper-cased state abbreviation (private helper)
+func parsePlace(query string) (string, string, bool) {
+	query = strings.TrimSpace(query)
+	var city, state string
+	if i := strings.LastIndex(query, ","); i >= 0 {
+		city, state = query[:i], query[i+1:]
+	} else if i := strings.LastIndex(query, " "); i >= 0 {
+		city, state = query[:i], query[i+1:]
+	} else {
+		return "", "", false
+	}
+
+	city = strings.Join(strings.Fields(city), " ")
+	state = strings.ToUpper(strings.TrimSpace(state))
+	if city == "" || !stateCodes[state] {
+		return "", "", false
+	}
+	return city, state, true
+}
+
+// PlaceQuery normalizes a free-text place name to the "City, ST" form the
+// geocoders expect, so spacing and the state's case don't split the cache
+func PlaceQuery(query string) (string, error) {
+	city, state, ok := parsePlace(query)
+	if !ok {
+		return "", ErrInvalidPlace
+	}
+	return city + ", " + state, nil
+}
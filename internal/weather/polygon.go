@@ -0,0 +1,124 @@
+package weather
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// MilesPerDegreeLat is the length of one degree of latitude, used for all
+// the distance math between coordinates
+const MilesPerDegreeLat = 69.0
+
+// compassPoints names bearings in 45 degree steps clockwise from north
+var compassPoints = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// parsePolygon reads the outer ring of a GeoJSON Polygon or the first polygon
+// of a MultiPolygon as [lat, lon] pairs. Zone-based alerts have no geometry,
+// which yields nil.
+func parsePolygon(geometry json.RawMessage) [][]float64 {
+	if len(geometry) == 0 || string(geometry) == "null" {
+		return nil
+	}
+
+	var geo struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(geometry, &geo); err != nil {
+		return nil
+	}
+
+	var rings [][][]float64
+	switch geo.Type {
+	case "Polygon":
+		if err := json.Unmarshal(geo.Coordinates, &rings); err != nil {
+			return nil
+		}
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(geo.Coordinates, &polygons); err != nil || len(polygons) == 0 {
+			return nil
+		}
+		rings = polygons[0]
+	default:
+		return nil
+	}
+
+	if len(rings) == 0 {
+		return nil
+	}
+
+	// GeoJSON positions are [lon, lat]
+	var polygon [][]float64
+	for _, pos := range rings[0] {
+		if len(pos) >= 2 {
+			polygon = append(polygon, []float64{pos[1], pos[0]})
+		}
+	}
+	return polygon
+}
+
+// BoundaryDistance returns how far the nearest edge of the alert polygon is
+// from the given point, in miles, and the compass direction towards it. ok is
+// false when the alert has no polygon.
+func (a Alert) BoundaryDistance(lat, lon float64) (miles float64, direction string, ok bool) {
+	if len(a.Polygon) < 2 {
+		return 0, "", false
+	}
+
+	// Work in a flat mile grid centered on the point; alert polygons are
+	// small enough that the distortion doesn't matter
+	milesPerDegreeLon := MilesPerDegreeLat * math.Cos(lat*math.Pi/180)
+	toMiles := func(p []float64) (float64, float64) {
+		return (p[1] - lon) * milesPerDegreeLon, (p[0] - lat) * MilesPerDegreeLat
+	}
+
+	best := math.Inf(1)
+	var bestX, bestY float64
+	for i := 0; i < len(a.Polygon)-1; i++ {
+		x1, y1 := toMiles(a.Polygon[i])
+		x2, y2 := toMiles(a.Polygon[i+1])
+
+		// Closest point to the origin on this edge
+		dx, dy := x2-x1, y2-y1
+		t := 0.0
+		if lenSq := dx*dx + dy*dy; lenSq > 0 {
+			t = math.Max(0, math.Min(1, -(x1*dx+y1*dy)/lenSq))
+		}
+		px, py := x1+t*dx, y1+t*dy
+
+		if d := math.Hypot(px, py); d < best {
+			best, bestX, bestY = d, px, py
+		}
+	}
+
+	bearing := math.Atan2(bestX, bestY) * 180 / math.Pi
+	if bearing < 0 {
+		bearing += 360
+	}
+	direction = compassPoints[int(math.Round(bearing/45))%len(compassPoints)]
+
+	return best, direction, true
+}
+
+// Centroid returns the average of the polygon's vertices, which is close
+// enough to the middle for the small convex shapes NWS draws. ok is false when
+// the alert has no polygon.
+func (a Alert) Centroid() (lat, lon float64, ok bool) {
+	points := a.Polygon
+	if len(points) == 0 {
+		return 0, 0, false
+	}
+
+	// Rings repeat the first vertex at the end; don't count it twice
+	if n := len(points); n > 1 && points[0][0] == points[n-1][0] && points[0][1] == points[n-1][1] {
+		points = points[:n-1]
+	}
+
+	for _, p := range points {
+		lat += p[0]
+		lon += p[1]
+	}
+	n := float64(len(points))
+	return lat / n, lon / n, true
+}
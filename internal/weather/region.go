@@ -0,0 +1,72 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupportedPrefix is returned for a ZIP prefix the embedded table has no
+// ZIP codes under. The table only holds major cities, so most real prefixes
+// are unsupported rather than unknown.
+var ErrUnsupportedPrefix = errors.New("ZIP prefix not supported")
+
+// IsZipPrefix reports whether query is a three-digit ZIP prefix, which
+// identifies a sectional center: a region covering part of a state
+func IsZipPrefix(query string) bool {
+	return len(query) == 3 && allDigits(query)
+}
+
+// ZipPrefixes returns the prefixes GeocodeZipPrefix supports, sorted
+func ZipPrefixes() []string {
+	seen := make(map[string]bool)
+	var prefixes []string
+	for zip := range loadOfflineTable() {
+		if prefix := zip[:3]; !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// GeocodeZipPrefix centers a region on the embedded table's ZIP codes that
+// start with prefix, named for the prefix and the state most of them are in.
+// The table holds a city or two per prefix, not every ZIP code, so only
+// ZipPrefixes can be resolved and the center is those cities'.
+func GeocodeZipPrefix(prefix string) (Location, error) {
+	table := loadOfflineTable()
+
+	var lat, lon float64
+	states := make(map[string]int)
+	count := 0
+	for zip, loc := range table {
+		if !strings.HasPrefix(zip, prefix) {
+			continue
+		}
+		lat += loc.Lat
+		lon += loc.Lon
+		states[loc.State]++
+		count++
+	}
+	if count == 0 {
+		return Location{}, fmt.Errorf("%w: %s has none of the built-in cities", ErrUnsupportedPrefix, prefix)
+	}
+
+	state := ""
+	for s, n := range states {
+		if n > states[state] || (n == states[state] && s < state) {
+			state = s
+		}
+	}
+
+	return Location{
+		Lat:    lat / float64(count),
+		Lon:    lon / float64(count),
+		City:   prefix + "xx region",
+		State:  state,
+		Source: OfflineGeocoder{}.Name(),
+	}, nil
+}
@@ -0,0 +1,180 @@
+package weather
+
+// radarStation is a NEXRAD (WSR-88D) site
+type radarStation struct {
+	id       string
+	lat, lon float64
+}
+
+// RadarStationLocation returns where a NEXRAD site stands, reporting false
+// for an unknown identifier
+func RadarStationLocation(id string) (float64, float64, bool) {
+	for _, s := range nexradStations {
+		if s.id == id {
+			return s.lat, s.lon, true
+		}
+	}
+	return 0, 0, false
+}
+
+// nexradStations is the NWS, Air Force and FAA WSR-88D network across the
+// US and its territories
+var nexradStations = []radarStation{
+	{"KABR", 45.4558, -98.4131},  // Aberdeen, SD
+	{"KABX", 35.1497, -106.8239}, // Albuquerque, NM
+	{"KAKQ", 36.9839, -77.0072},  // Wakefield, VA
+	{"KAMA", 35.2333, -101.7092}, // Amarillo, TX
+	{"KAMX", 25.6111, -80.4128},  // Miami, FL
+	{"KAPX", 44.9072, -84.7197},  // Gaylord, MI
+	{"KARX", 43.8228, -91.1911},  // La Crosse, WI
+	{"KATX", 48.1945, -122.4958}, // Seattle, WA
+	{"KBBX", 39.4961, -121.6317}, // Beale AFB, CA
+	{"KBGM", 42.1997, -75.9847},  // Binghamton, NY
+	{"KBHX", 40.4986, -124.2919}, // Eureka, CA
+	{"KBIS", 46.7708, -100.7603}, // Bismarck, ND
+	{"KBLX", 45.8538, -108.6068}, // Billings, MT
+	{"KBMX", 33.1722, -86.7697},  // Birmingham, AL
+	{"KBOX", 41.9558, -71.1369},  // Boston, MA
+	{"KBRO", 25.9161, -97.4189},  // Brownsville, TX
+	{"KBUF", 42.9489, -78.7367},  // Buffalo, NY
+	{"KBYX", 24.5975, -81.7031},  // Key West, FL
+	{"KCAE", 33.9486, -81.1183},  // Columbia, SC
+	{"KCBW", 46.0392, -67.8067},  // Houlton, ME
+	{"KCBX", 43.4906, -116.2361}, // Boise, ID
+	{"KCCX", 40.9231, -78.0036},  // State College, PA
+	{"KCLE", 41.4131, -81.8597},  // Cleveland, OH
+	{"KCLX", 32.6556, -81.0422},  // Charleston, SC
+	{"KCRP", 27.7842, -97.5111},  // Corpus Christi, TX
+	{"KCXX", 44.5111, -73.1664},  // Burlington, VT
+	{"KCYS", 41.1519, -104.8061}, // Cheyenne, WY
+	{"KDAX", 38.5011, -121.6778}, // Sacramento, CA
+	{"KDDC", 37.7608, -99.9689},  // Dodge City, KS
+	{"KDFX", 29.2728, -100.2806}, // Laughlin AFB, TX
+	{"KDGX", 32.2800, -89.9844},  // Jackson, MS
+	{"KDIX", 39.9469, -74.4108},  // Philadelphia, PA
+	{"KDLH", 46.8369, -92.2097},  // Duluth, MN
+	{"KDMX", 41.7311, -93.7228},  // Des Moines, IA
+	{"KDOX", 38.8256, -75.4400},  // Dover AFB, DE
+	{"KDTX", 42.7000, -83.4717},  // Detroit, MI
+	{"KDVN", 41.6117, -90.5808},  // Davenport, IA
+	{"KDYX", 32.5383, -99.2542},  // Dyess AFB, TX
+	{"KEAX", 38.8103, -94.2644},  // Kansas City, MO
+	{"KEMX", 31.8936, -110.6303}, // Tucson, AZ
+	{"KENX", 42.5864, -74.0639},  // Albany, NY
+	{"KEOX", 31.4606, -85.4594},  // Fort Rucker, AL
+	{"KEPZ", 31.8731, -106.6981}, // El Paso, TX
+	{"KESX", 35.7011, -114.8914}, // Las Vegas, NV
+	{"KEVX", 30.5644, -85.9214},  // Eglin AFB, FL
+	{"KEWX", 29.7039, -98.0286},  // Austin/San Antonio, TX
+	{"KEYX", 35.0978, -117.5608}, // Edwards AFB, CA
+	{"KFCX", 37.0244, -80.2739},  // Roanoke, VA
+	{"KFDR", 34.3622, -98.9764},  // Frederick, OK
+	{"KFDX", 34.6353, -103.6297}, // Cannon AFB, NM
+	{"KFFC", 33.3636, -84.5658},  // Atlanta, GA
+	{"KFSD", 43.5878, -96.7294},  // Sioux Falls, SD
+	{"KFSX", 34.5744, -111.1978}, // Flagstaff, AZ
+	{"KFTG", 39.7867, -104.5458}, // Denver, CO
+	{"KFWS", 32.5731, -97.3031},  // Dallas/Fort Worth, TX
+	{"KGGW", 48.2064, -106.6253}, // Glasgow, MT
+	{"KGJX", 39.0622, -108.2139}, // Grand Junction, CO
+	{"KGLD", 39.3667, -101.7003}, // Goodland, KS
+	{"KGRB", 44.4986, -88.1114},  // Green Bay, WI
+	{"KGRK", 30.7219, -97.3831},  // Fort Hood, TX
+	{"KGRR", 42.8939, -85.5447},  // Grand Rapids, MI
+	{"KGSP", 34.8833, -82.2200},  // Greenville/Spartanburg, SC
+	{"KGWX", 33.8967, -88.3292},  // Columbus AFB, MS
+	{"KGYX", 43.8914, -70.2567},  // Portland, ME
+	{"KHDC", 30.5193, -90.4074},  // Hammond, LA
+	{"KHDX", 33.0764, -106.1222}, // Holloman AFB, NM
+	{"KHGX", 29.4719, -95.0792},  // Houston, TX
+	{"KHNX", 36.3142, -119.6322}, // San Joaquin Valley, CA
+	{"KHPX", 36.7367, -87.2856},  // Fort Campbell, KY
+	{"KHTX", 34.9306, -86.0836},  // Huntsville, AL
+	{"KICT", 37.6547, -97.4428},  // Wichita, KS
+	{"KICX", 37.5911, -112.8622}, // Cedar City, UT
+	{"KILN", 39.4203, -83.8217},  // Cincinnati/Wilmington, OH
+	{"KILX", 40.1506, -89.3367},  // Lincoln, IL
+	{"KIND", 39.7075, -86.2803},  // Indianapolis, IN
+	{"KINX", 36.1750, -95.5644},  // Tulsa, OK
+	{"KIWA", 33.2892, -111.6700}, // Phoenix, AZ
+	{"KIWX", 41.3589, -85.7000},  // Northern Indiana, IN
+	{"KJAX", 30.4847, -81.7019},  // Jacksonville, FL
+	{"KJGX", 32.6756, -83.3511},  // Robins AFB, GA
+	{"KJKL", 37.5908, -83.3131},  // Jackson, KY
+	{"KLBB", 33.6539, -101.8142}, // Lubbock, TX
+	{"KLCH", 30.1253, -93.2158},  // Lake Charles, LA
+	{"KLGX", 47.1158, -124.1069}, // Langley Hill, WA
+	{"KLIX", 30.3367, -89.8256},  // New Orleans, LA
+	{"KLNX", 41.9578, -100.5764}, // North Platte, NE
+	{"KLOT", 41.6045, -88.0847},  // Chicago, IL
+	{"KLRX", 40.7397, -116.8028}, // Elko, NV
+	{"KLSX", 38.6989, -90.6828},  // St. Louis, MO
+	{"KLTX", 33.9892, -78.4292},  // Wilmington, NC
+	{"KLVX", 37.9753, -85.9439},  // Louisville, KY
+	{"KLWX", 38.9753, -77.4778},  // Washington, DC
+	{"KLZK", 34.8364, -92.2622},  // Little Rock, AR
+	{"KMAF", 31.9433, -102.1892}, // Midland/Odessa, TX
+	{"KMAX", 42.0811, -122.7175}, // Medford, OR
+	{"KMBX", 48.3925, -100.8644}, // Minot AFB, ND
+	{"KMHX", 34.7761, -76.8761},  // Morehead City, NC
+	{"KMKX", 42.9678, -88.5506},  // Milwaukee, WI
+	{"KMLB", 28.1133, -80.6542},  // Melbourne, FL
+	{"KMOB", 30.6794, -88.2397},  // Mobile, AL
+	{"KMPX", 44.8489, -93.5653},  // Minneapolis, MN
+	{"KMQT", 46.5311, -87.5483},  // Marquette, MI
+	{"KMRX", 36.1683, -83.4017},  // Knoxville, TN
+	{"KMSX", 47.0411, -113.9861}, // Missoula, MT
+	{"KMTX", 41.2628, -112.4478}, // Salt Lake City, UT
+	{"KMUX", 37.1553, -121.8983}, // San Francisco, CA
+	{"KMVX", 47.5278, -97.3256},  // Grand Forks, ND
+	{"KMXX", 32.5367, -85.7897},  // Maxwell AFB, AL
+	{"KNKX", 32.9189, -117.0419}, // San Diego, CA
+	{"KNQA", 35.3447, -89.8733},  // Memphis, TN
+	{"KOAX", 41.3203, -96.3667},  // Omaha, NE
+	{"KOHX", 36.2472, -86.5625},  // Nashville, TN
+	{"KOKX", 40.8653, -72.8639},  // New York, NY
+	{"KOTX", 47.6803, -117.6267}, // Spokane, WA
+	{"KPAH", 37.0683, -88.7719},  // Paducah, KY
+	{"KPBZ", 40.5317, -80.2183},  // Pittsburgh, PA
+	{"KPDT", 45.6906, -118.8528}, // Pendleton, OR
+	{"KPOE", 31.1556, -92.9758},  // Fort Polk, LA
+	{"KPUX", 38.4594, -104.1814}, // Pueblo, CO
+	{"KRAX", 35.6656, -78.4897},  // Raleigh/Durham, NC
+	{"KRGX", 39.7542, -119.4622}, // Reno, NV
+	{"KRIW", 43.0661, -108.4772}, // Riverton, WY
+	{"KRLX", 38.3111, -81.7231},  // Charleston, WV
+	{"KRTX", 45.7150, -122.9650}, // Portland, OR
+	{"KSFX", 43.1056, -112.6861}, // Pocatello, ID
+	{"KSGF", 37.2355, -93.4003},  // Springfield, MO
+	{"KSHV", 32.4508, -93.8414},  // Shreveport, LA
+	{"KSJT", 31.3711, -100.4925}, // San Angelo, TX
+	{"KSOX", 33.8178, -117.6358}, // Santa Ana Mountains, CA
+	{"KSRX", 35.2906, -94.3619},  // Fort Smith, AR
+	{"KTBW", 27.7056, -82.4017},  // Tampa Bay, FL
+	{"KTFX", 47.4595, -111.3855}, // Great Falls, MT
+	{"KTLH", 30.3975, -84.3289},  // Tallahassee, FL
+	{"KTLX", 35.3331, -97.2778},  // Oklahoma City, OK
+	{"KTWX", 38.9969, -96.2325},  // Topeka, KS
+	{"KTYX", 43.7558, -75.6800},  // Montague, NY
+	{"KUDX", 44.1250, -102.8297}, // Rapid City, SD
+	{"KUEX", 40.3208, -98.4419},  // Hastings, NE
+	{"KVAX", 30.8903, -83.0019},  // Moody AFB, GA
+	{"KVBX", 34.8383, -120.3978}, // Vandenberg SFB, CA
+	{"KVNX", 36.7408, -98.1278},  // Vance AFB, OK
+	{"KVTX", 34.4117, -119.1794}, // Los Angeles, CA
+	{"KVWX", 38.2603, -87.7247},  // Evansville, IN
+	{"KYUX", 32.4953, -114.6567}, // Yuma, AZ
+	{"PABC", 60.7919, -161.8764}, // Bethel, AK
+	{"PACG", 56.8528, -135.5292}, // Sitka, AK
+	{"PAEC", 64.5114, -165.2950}, // Nome, AK
+	{"PAHG", 60.7258, -151.3514}, // Anchorage, AK
+	{"PAIH", 59.4614, -146.3033}, // Middleton Island, AK
+	{"PAKC", 58.6794, -156.6294}, // King Salmon, AK
+	{"PAPD", 65.0350, -147.5014}, // Fairbanks, AK
+	{"PGUA", 13.4558, 144.8111},  // Andersen AFB, Guam
+	{"PHKI", 21.8939, -159.5525}, // South Kauai, HI
+	{"PHKM", 20.1253, -155.7781}, // Kohala, HI
+	{"PHMO", 21.1328, -157.1803}, // Molokai, HI
+	{"PHWA", 19.0950, -155.5689}, // South Shore, HI
+	{"TJUA", 18.1156, -66.0781},  // San Juan, PR
+}
@@ -0,0 +1,140 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// windGridSize is the number of sample points along each side of the wind grid
+const windGridSize = 3
+
+// WindVector is the forecast wind at a single grid point
+type WindVector struct {
+	Lat       float64
+	Lon       float64
+	SpeedMPH  int
+	Direction string // compass point the wind blows from, e.g. "NW"
+}
+
+// FetchWindGrid samples the hourly forecast wind on a coarse grid spanning the
+// radar view centered on lat/lon. Points the NWS can't forecast, or that
+// aren't fetched before ctx is done, are omitted.
+func FetchWindGrid(ctx context.Context, lat, lon float64) []WindVector {
+	client := httpclient.New(httpclient.APITimeout)
+
+	// Space samples a third of the view apart, so the outer ones sit a sixth
+	// of the way in from each edge
+	latStep := config.ViewHeightMiles / windGridSize / 69.0
+	lonStep := config.ViewWidthMiles / windGridSize / (69.0 * math.Cos(lat*math.Pi/180))
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		winds []WindVector
+	)
+
+	for row := 0; row < windGridSize; row++ {
+		for col := 0; col < windGridSize; col++ {
+			pointLat := lat + float64(windGridSize/2-row)*latStep
+			pointLon := lon + float64(col-windGridSize/2)*lonStep
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				wind, err := fetchPointWind(ctx, client, pointLat, pointLon)
+				if err != nil {
+					log.Printf("No forecast wind at %.2f,%.2f: %v", pointLat, pointLon, err)
+					return
+				}
+				mu.Lock()
+				winds = append(winds, wind)
+				mu.Unlock()
+			}()
+		}
+	}
+
+	wg.Wait()
+	return winds
+}
+
+// fetchPointWind returns the first hourly forecast period's wind for a point (private helper)
+func fetchPointWind(ctx context.Context, client *http.Client, lat, lon float64) (WindVector, error) {
+	pointURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
+
+	resp, err := httpclient.Fetch(ctx, client, pointURL)
+	if err != nil {
+		return WindVector{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return WindVector{}, fmt.Errorf("points API returned status %d", resp.StatusCode)
+	}
+
+	var pointData struct {
+		Properties struct {
+			ForecastHourlyURL string `json:"forecastHourly"`
+		} `json:"properties"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&pointData); err != nil {
+		return WindVector{}, err
+	}
+
+	hourlyResp, err := httpclient.Fetch(ctx, client, pointData.Properties.ForecastHourlyURL)
+	if err != nil {
+		return WindVector{}, err
+	}
+	defer hourlyResp.Body.Close()
+
+	if hourlyResp.StatusCode != http.StatusOK {
+		return WindVector{}, fmt.Errorf("hourly forecast returned status %d", hourlyResp.StatusCode)
+	}
+
+	var hourly struct {
+		Properties struct {
+			Periods []struct {
+				WindSpeed     string `json:"windSpeed"`
+				WindDirection string `json:"windDirection"`
+			} `json:"periods"`
+		} `json:"properties"`
+	}
+
+	if err := json.NewDecoder(hourlyResp.Body).Decode(&hourly); err != nil {
+		return WindVector{}, err
+	}
+
+	if len(hourly.Properties.Periods) == 0 {
+		return WindVector{}, fmt.Errorf("no hourly periods")
+	}
+
+	period := hourly.Properties.Periods[0]
+	return WindVector{
+		Lat:       lat,
+		Lon:       lon,
+		SpeedMPH:  parseWindSpeed(period.WindSpeed),
+		Direction: period.WindDirection,
+	}, nil
+}
+
+// parseWindSpeed extracts the top speed from strings like "10 mph" or
+// "5 to 15 mph" (private helper)
+func parseWindSpeed(speed string) int {
+	top := 0
+	for _, field := range strings.Fields(speed) {
+		if n, err := strconv.Atoi(field); err == nil && n > top {
+			top = n
+		}
+	}
+	return top
+}
@@ -0,0 +1,264 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/httpclient"
+)
+
+// WinterHours is how far ahead the snowfall and ice forecast is summed
+const WinterHours = 24
+
+// winterCelsius is the temperature at or below which winter data is worth
+// fetching even without a winter alert or snowy conditions
+const winterCelsius = 4.0
+
+// mmPerInch converts METAR snow depths, which are reported in inches
+const mmPerInch = 25.4
+
+// Winter is the snow and ice picture at a point. Each field is nil when the
+// NWS didn't provide it.
+type Winter struct {
+	// SnowDepthMM is the snow on the ground at the observation station
+	SnowDepthMM *float64
+	// SnowfallMM is the forecast snowfall over the next WinterHours
+	SnowfallMM *float64
+	// IceMM is the forecast ice accumulation over the next WinterHours
+	IceMM *float64
+}
+
+// Empty reports whether there is nothing to show: every field is missing or
+// zero
+func (w Winter) Empty() bool {
+	for _, value := range []*float64{w.SnowDepthMM, w.SnowfallMM, w.IceMM} {
+		if value != nil && *value > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsWinterAlert reports whether an alert is a winter product, such as a
+// Winter Storm Warning, Blizzard Warning or Ice Storm Warning
+func IsWinterAlert(alert Alert) bool {
+	event := strings.ToLower(alert.Event)
+	for _, word := range []string{"winter", "snow", "blizzard", "ice storm", "freezing", "sleet"} {
+		if strings.Contains(event, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// WinterLikely reports whether winter data is worth fetching: a winter alert
+// is in effect, the conditions mention snow or ice, or it's near freezing
+func WinterLikely(alerts []Alert, obs Observation) bool {
+	for _, alert := range alerts {
+		if IsWinterAlert(alert) {
+			return true
+		}
+	}
+
+	conditions := strings.ToLower(obs.Conditions)
+	for _, word := range []string{"snow", "sleet", "ice", "freezing", "flurries"} {
+		if strings.Contains(conditions, word) {
+			return true
+		}
+	}
+	return obs.Celsius != nil && *obs.Celsius <= winterCelsius
+}
+
+// FetchWinter returns the forecast snowfall and ice accumulation for a point
+// from the NWS gridpoint data, with the snow depth from stationID's latest
+// observation when it reports one. Whatever can't be fetched is left nil.
+func FetchWinter(ctx context.Context, lat, lon float64, stationID string) Winter {
+	client := httpclient.New(httpclient.APITimeout)
+
+	var winter Winter
+	if snowfall, ice, err := fetchWinterForecast(ctx, client, lat, lon); err == nil {
+		winter.SnowfallMM, winter.IceMM = snowfall, ice
+	}
+	if stationID != "" {
+		winter.SnowDepthMM = fetchSnowDepth(ctx, client, stationID)
+	}
+	return winter
+}
+
+// gridSeries is one quantitative layer of the NWS gridpoint data
+type gridSeries struct {
+	UOM    string `json:"uom"`
+	Values []struct {
+		ValidTime string   `json:"validTime"`
+		Value     *float64 `json:"value"`
+	} `json:"values"`
+}
+
+// fetchWinterForecast sums the gridpoint snowfall and ice accumulation over
+// the next WinterHours (private helper)
+func fetchWinterForecast(ctx context.Context, client *http.Client, lat, lon float64) (*float64, *float64, error) {
+	pointURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
+	resp, err := httpclient.Fetch(ctx, client, pointURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("points API returned status %d", resp.StatusCode)
+	}
+
+	var pointData struct {
+		Properties struct {
+			ForecastGridDataURL string `json:"forecastGridData"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pointData); err != nil {
+		return nil, nil, err
+	}
+	if pointData.Properties.ForecastGridDataURL == "" {
+		return nil, nil, fmt.Errorf("no gridpoint forecast for this point")
+	}
+
+	gridResp, err := httpclient.Fetch(ctx, client, pointData.Properties.ForecastGridDataURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer gridResp.Body.Close()
+
+	if gridResp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("gridpoint forecast returned status %d", gridResp.StatusCode)
+	}
+
+	var grid struct {
+		Properties struct {
+			SnowfallAmount  gridSeries `json:"snowfallAmount"`
+			IceAccumulation gridSeries `json:"iceAccumulation"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(gridResp.Body).Decode(&grid); err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	end := now.Add(WinterHours * time.Hour)
+	return grid.Properties.SnowfallAmount.sumMM(now, end), grid.Properties.IceAccumulation.sumMM(now, end), nil
+}
+
+// sumMM totals the values whose valid time overlaps from..to, in
+// millimetres. It returns nil when no value overlaps, so a forecast of zero
+// stays distinct from no forecast.
+func (s gridSeries) sumMM(from, to time.Time) *float64 {
+	scale := 1.0
+	if strings.HasSuffix(s.UOM, "cm") {
+		scale = 10
+	} else if strings.HasSuffix(s.UOM, "in") {
+		scale = mmPerInch
+	}
+
+	var total float64
+	found := false
+	for _, v := range s.Values {
+		if v.Value == nil {
+			continue
+		}
+		start, length, ok := parseValidTime(v.ValidTime)
+		if !ok || !start.Before(to) || !start.Add(length).After(from) {
+			continue
+		}
+		total += *v.Value * scale
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return &total
+}
+
+// parseValidTime splits a gridpoint valid time such as
+// "2024-01-10T12:00:00+00:00/PT6H" into its start and length (private helper)
+func parseValidTime(validTime string) (time.Time, time.Duration, bool) {
+	startText, durationText, ok := strings.Cut(validTime, "/")
+	if !ok {
+		return time.Time{}, 0, false
+	}
+	start, err := time.Parse(time.RFC3339, startText)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	length, ok := parseISODuration(durationText)
+	return start, length, ok
+}
+
+// isoDurationPart matches one number-and-unit part of an ISO 8601 duration
+var isoDurationPart = regexp.MustCompile(`(\d+)([DHMS])`)
+
+// parseISODuration reads the day and time durations the gridpoint data uses,
+// such as "PT6H" or "P1DT12H" (private helper)
+func parseISODuration(text string) (time.Duration, bool) {
+	if !strings.HasPrefix(text, "P") {
+		return 0, false
+	}
+	days, clock, _ := strings.Cut(text[1:], "T")
+
+	var total time.Duration
+	for i, part := range []string{days, clock} {
+		for _, match := range isoDurationPart.FindAllStringSubmatch(part, -1) {
+			n, _ := strconv.Atoi(match[1])
+			switch {
+			case i == 0 && match[2] == "D":
+				total += time.Duration(n) * 24 * time.Hour
+			case i == 1 && match[2] == "H":
+				total += time.Duration(n) * time.Hour
+			case i == 1 && match[2] == "M":
+				total += time.Duration(n) * time.Minute
+			case i == 1 && match[2] == "S":
+				total += time.Duration(n) * time.Second
+			}
+		}
+	}
+	return total, total > 0
+}
+
+// snowDepthGroup matches the METAR remark reporting snow depth in whole
+// inches, e.g. "4/012"
+var snowDepthGroup = regexp.MustCompile(`\s4/(\d{3})\b`)
+
+// fetchSnowDepth reads the snow depth from the remarks of a station's latest
+// METAR. Stations only include it in some reports, so nil is common.
+// (private helper)
+func fetchSnowDepth(ctx context.Context, client *http.Client, stationID string) *float64 {
+	obsURL := fmt.Sprintf("https://api.weather.gov/stations/%s/observations/latest", stationID)
+	resp, err := httpclient.Fetch(ctx, client, obsURL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	var obsData struct {
+		Properties struct {
+			RawMessage string `json:"rawMessage"`
+		} `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&obsData); err != nil {
+		return nil
+	}
+
+	_, remarks, ok := strings.Cut(obsData.Properties.RawMessage, " RMK ")
+	if !ok {
+		return nil
+	}
+	match := snowDepthGroup.FindStringSubmatch(" " + remarks)
+	if match == nil {
+		return nil
+	}
+	inches, _ := strconv.Atoi(match[1])
+	depth := float64(inches) * mmPerInch
+	return &depth
+}
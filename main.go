@@ -1,16 +1,93 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/N-Erickson/termidar/internal/cache"
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/fixtures"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+	"github.com/N-Erickson/termidar/internal/radar"
 	"github.com/N-Erickson/termidar/internal/ui"
 )
 
 
 func main() {
-	p := tea.NewProgram(ui.InitialModel(), tea.WithAltScreen())
+	latest := flag.Bool("latest", false, "start paused on the most recent frame instead of playing the loop")
+	offline := flag.Bool("offline", false, "serve embedded sample data instead of contacting any network service")
+	clearCache := flag.Bool("clear-cache", false, "delete all cached data and exit")
+	resume := flag.Bool("resume", false, "reopen the most recently viewed location instead of asking for a ZIP code")
+	replayAt := flag.String("time", "", "replay archived radar around this time (\"YYYY-MM-DD HH:MM\" local, or RFC 3339)")
+	private := flag.Bool("private", false, "hide the searched location, its marker and the typed ZIP code, for screenshots and streams")
+	selftest := flag.Bool("selftest", false, "load and render the embedded sample data, then exit 0 if it worked and 1 if not")
+	jsonQuery := flag.String("json", "", "print the current conditions and alerts for a ZIP code or place as JSON and exit, without starting the UI")
+	flag.Parse()
+
+	if *selftest {
+		summary, err := runSelftest()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Selftest failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Selftest passed: %s\n", summary)
+		return
+	}
+
+	if *clearCache {
+		if err := cache.Clear(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error clearing cache: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Cache cleared")
+		return
+	}
+
+	prefs := config.LoadPreferences()
+
+	if *offline {
+		httpclient.Transport = fixtures.Transport()
+		// Keep sample responses out of the real cache
+		cache.Configure(cache.Settings{})
+	} else {
+		cache.Configure(prefs.CacheSettings())
+	}
+	if *jsonQuery != "" {
+		if err := runReport(*jsonQuery, prefs, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *latest {
+		prefs.StartMode = config.StartModeLatest
+	}
+
+	if prefs.CustomSource != "" {
+		if _, err := radar.ParseSourceTemplate(prefs.CustomSource); err != nil {
+			fmt.Fprintf(os.Stderr, "Error in custom_source: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	model := ui.NewModel(prefs).WithPrivate(*private)
+	if *replayAt != "" {
+		t, err := radar.ParseReplayTime(*replayAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+		model = model.WithReplayTime(t)
+	}
+	if *resume || prefs.ResumeLast {
+		model = model.WithResume(ui.LastLocation())
+	}
+
+	p := tea.NewProgram(model, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
 	}
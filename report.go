@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/radar"
+)
+
+// report is the --json output: the searched location's current conditions
+// and active alerts
+type report struct {
+	Query              string        `json:"query"`
+	Location           string        `json:"location"`
+	Lat                float64       `json:"lat"`
+	Lon                float64       `json:"lon"`
+	Station            string        `json:"station"`
+	ObservationStation string        `json:"observation_station,omitempty"`
+	TemperatureC       *float64      `json:"temperature_c"`
+	TemperatureF       *float64      `json:"temperature_f"`
+	Conditions         string        `json:"conditions"`
+	Alerts             []reportAlert `json:"alerts"`
+	Updated            time.Time     `json:"updated"`
+}
+
+type reportAlert struct {
+	Event    string    `json:"event"`
+	Severity string    `json:"severity"`
+	Urgency  string    `json:"urgency"`
+	Headline string    `json:"headline"`
+	Expires  time.Time `json:"expires,omitzero"`
+}
+
+// runReport loads query through the normal pipeline and writes its
+// conditions and alerts to w as JSON. It returns the load's error if the
+// location couldn't be found or fetched, and an error in place of the report
+// if the conditions or alerts couldn't be, so a failed fetch is never
+// mistaken for a quiet day.
+func runReport(query string, prefs config.Preferences, w io.Writer) error {
+	msg := radar.Await(radar.LoadData(query, radar.Options{
+		ObservationStations: prefs.ObservationStations(),
+		Timeout:             prefs.LoadTimeout(),
+		// Frames aren't reported, so don't download them
+		ForceSource: config.RadarSourceSimulated,
+	}))
+	var data radar.Data
+	switch msg := msg.(type) {
+	case radar.LoadedMsg:
+		data = msg.Radar
+	case radar.ErrorMsg:
+		return msg.Err
+	default:
+		return fmt.Errorf("load returned unexpected %T", msg)
+	}
+	if err := data.FetchErrors.Err(); err != nil {
+		return fmt.Errorf("fetching weather for %s: %w", query, err)
+	}
+
+	out := report{
+		Query:              query,
+		Location:           data.Location,
+		Lat:                data.HomeLat,
+		Lon:                data.HomeLon,
+		Station:            data.Station,
+		ObservationStation: data.ObservationStation,
+		TemperatureC:       data.Celsius,
+		Conditions:         data.Conditions,
+		Alerts:             []reportAlert{},
+		Updated:            data.LastUpdated,
+	}
+	if data.Celsius != nil {
+		fahrenheit := math.Round((*data.Celsius*9/5+32)*10) / 10
+		out.TemperatureF = &fahrenheit
+	}
+	for _, alert := range data.Alerts {
+		out.Alerts = append(out.Alerts, reportAlert{
+			Event:    alert.Event,
+			Severity: alert.Severity,
+			Urgency:  alert.Urgency,
+			Headline: alert.Headline,
+			Expires:  alert.Expires,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/N-Erickson/termidar/internal/cache"
+	"github.com/N-Erickson/termidar/internal/config"
+	"github.com/N-Erickson/termidar/internal/fixtures"
+	"github.com/N-Erickson/termidar/internal/httpclient"
+	"github.com/N-Erickson/termidar/internal/radar"
+	"github.com/N-Erickson/termidar/internal/ui"
+)
+
+// selftestZip is a location the embedded fixtures cover
+const selftestZip = "10001"
+
+// runSelftest loads radar for a fixture location through the normal pipeline
+// (geocoding, image decode, projection) and renders it, returning a
+// description of the result or the first thing that was wrong with it
+func runSelftest() (summary string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	httpclient.Transport = fixtures.Transport()
+	cache.Configure(cache.Settings{})
+	prefs := config.DefaultPreferences()
+
+	msg := radar.Await(radar.LoadData(selftestZip, radar.Options{
+		ObservationStations: prefs.ObservationStations(),
+		FrameInterval:       prefs.FrameInterval(),
+		Timeout:             prefs.LoadTimeout(),
+		RainViewerRetries:   prefs.RainViewerRetries,
+	}))
+	var data radar.Data
+	switch msg := msg.(type) {
+	case radar.LoadedMsg:
+		data = msg.Radar
+	case radar.ErrorMsg:
+		return "", fmt.Errorf("load failed: %w", msg.Err)
+	default:
+		return "", fmt.Errorf("load returned unexpected %T", msg)
+	}
+
+	if err := checkRadarData(data); err != nil {
+		return "", err
+	}
+
+	model := ui.NewModel(prefs).WithResume(selftestZip).WithSize(200, 60)
+	updated, _ := model.Update(radar.LoadedMsg{Radar: data})
+	view := updated.View()
+	if strings.TrimSpace(view) == "" {
+		return "", errors.New("rendered view is empty")
+	}
+	if !strings.Contains(view, data.Location) {
+		return "", fmt.Errorf("rendered view doesn't mention %q", data.Location)
+	}
+
+	return fmt.Sprintf("%d frames from %s for %s", len(data.Frames), data.Frames[0].Source, data.Location), nil
+}
+
+// checkRadarData reports the first way data falls short of a real load
+func checkRadarData(data radar.Data) error {
+	if len(data.Frames) == 0 {
+		return errors.New("no frames")
+	}
+	if !data.IsRealData {
+		return errors.New("fell back to simulated data")
+	}
+	if data.Location == "" {
+		return errors.New("no location name")
+	}
+
+	precip := false
+	for i, frame := range data.Frames {
+		if frame.Timestamp.IsZero() {
+			return fmt.Errorf("frame %d has no timestamp", i)
+		}
+		if len(frame.Data) != config.RadarHeight {
+			return fmt.Errorf("frame %d has %d rows, want %d", i, len(frame.Data), config.RadarHeight)
+		}
+		for y, row := range frame.Data {
+			if len(row) != config.RadarWidth {
+				return fmt.Errorf("frame %d row %d has %d cells, want %d", i, y, len(row), config.RadarWidth)
+			}
+			for x, v := range row {
+				if v < 0 || v > radar.MaxIntensity {
+					return fmt.Errorf("frame %d cell (%d,%d) has intensity %d", i, x, y, v)
+				}
+			}
+		}
+		if frame.HasPrecip != radar.HasEchoes(frame.Data) {
+			return fmt.Errorf("frame %d HasPrecip is %v but its grid disagrees", i, frame.HasPrecip)
+		}
+		precip = precip || frame.HasPrecip
+	}
+	if !precip {
+		return errors.New("no frame decoded any precipitation from the sample imagery")
+	}
+	return nil
+}
@@ -92,7 +92,6 @@ cd termidar
 # Install dependencies
 go get github.com/charmbracelet/ssh
 go get github.com/charmbracelet/wish
-go get github.com/charmbracelet/wish/activeterm
 go get github.com/charmbracelet/wish/bubbletea
 go get github.com/muesli/termenv
 
@@ -112,12 +111,13 @@ import (
     tea "github.com/charmbracelet/bubbletea"
     "github.com/charmbracelet/ssh"
     "github.com/charmbracelet/wish"
-    "github.com/charmbracelet/wish/activeterm"
     "github.com/charmbracelet/wish/bubbletea"
     "github.com/charmbracelet/lipgloss"
     "github.com/muesli/termenv"
     
+    "github.com/N-Erickson/termidar/internal/config"
     "github.com/N-Erickson/termidar/internal/ui"
+    "github.com/N-Erickson/termidar/internal/weather"
 )
 
 func main() {
@@ -135,9 +135,13 @@ func main() {
         wish.WithPasswordAuth(func(ctx ssh.Context, pass string) bool {
             return true
         }),
+        // Middleware runs last to first: sessions without a PTY are turned
+        // away before a program starts. The bubbletea middleware forwards
+        // the session's window changes to the program as tea.WindowSizeMsg,
+        // so the layout reflows when the client's window is resized.
         wish.WithMiddleware(
             bubbletea.Middleware(teaHandler),
-            activeterm.Middleware(),
+            requirePTY(),
         ),
     )
     if err != nil {
@@ -161,8 +165,25 @@ func main() {
     s.Shutdown(ctx)
 }
 
+// requirePTY ends sessions that have no terminal, such as "ssh host 10001"
+// without -t or a command piped through ssh, with a hint instead of a
+// program that can never draw or resize
+func requirePTY() wish.Middleware {
+    return func(next ssh.Handler) ssh.Handler {
+        return func(s ssh.Session) {
+            if _, _, active := s.Pty(); active {
+                next(s)
+                return
+            }
+            wish.Println(s, "termidar needs an interactive terminal. Connect with: ssh -t termidar.app")
+            _ = s.Exit(1)
+        }
+    }
+}
+
 func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
-    // Get PTY info
+    // Get PTY info; requirePTY has already turned away sessions without one,
+    // and window changes reach the model through the bubbletea middleware
     pty, _, _ := s.Pty()
     
     // Force color environment
@@ -173,7 +194,23 @@ func teaHandler(s ssh.Session) (tea.Model, []tea.ProgramOption) {
         os.Setenv("TERM", "xterm-256color")
     }
     
-    m := ui.InitialModel()
+    // Visitors share this process, so each session keeps its searches,
+    // favorites and settings in memory rather than in the host's config
+    // directory, starting from the host's saved preferences. Some clients
+    // never send a window change, so start at the PTY size.
+    store := config.NewMemoryStore(config.LoadPreferences())
+    m := ui.NewModelWithStore(store.LoadPreferences(), store).
+        WithSize(pty.Window.Width, pty.Window.Height).
+        // Units follow the client's forwarded locale, not the server's
+        WithLocale(s.Environ()).
+        // Exports would be written to the server's disk, not the visitor's
+        WithExports(false)
+
+    // "ssh -t host 10001" opens that ZIP code's radar straight away; any
+    // other argument gets the usual location input
+    if args := s.Command(); len(args) > 0 && (weather.IsZipCode(args[0]) || weather.IsZipPrefix(args[0])) {
+        m = m.WithResume(args[0])
+    }
     
     return m, []tea.ProgramOption{
         tea.WithAltScreen(),
@@ -242,6 +279,7 @@ if systemctl is-active --quiet termidar-ssh; then
     echo ""
     echo "Termidar SSH is now on port 22 with full color support"
     echo "Users can connect: ssh termidar.app"
+    echo "Or open a ZIP code directly: ssh -t termidar.app 10001"
     echo ""
     echo "For server management: ssh -p 2222 opc@your-ip"
     echo ""